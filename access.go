@@ -0,0 +1,230 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// sketchDepth is the number of rows of the count-min sketch
+const sketchDepth = 4
+
+// KeyCount is a key together with its approximate number of reads
+type KeyCount struct {
+	Key   interface{} // Key that was read
+	Count uint64      // Approximate number of reads that found the key
+}
+
+// accessCounter estimates read counts with a count-min sketch and keeps
+// the keys with the highest estimates in a min-heap
+type accessCounter struct {
+	mu    sync.Mutex            // Guards the fields below; reads may run concurrently
+	seed  maphash.Seed          // Seed for hashing keys
+	width int                   // Number of counters per row
+	rows  [sketchDepth][]uint64 // Counters of the sketch
+	topK  int                   // Number of keys to track
+	heap  []KeyCount            // Tracked keys, lowest count first
+	index map[interface{}]int   // Position of each tracked key in heap, by countKey
+}
+
+// nanKey stands for every NaN key of a float type in the index of an
+// accessCounter, since NaN never equals itself
+type nanKey struct {
+	t reflect.Type // Float type of the key
+}
+
+// countKey returns key in a form that is equal under == for keys the skip
+// list holds as one: -0 becomes 0 and every NaN the same nanKey. Keys have
+// passed checkKey, so they are of an ordered type and comparable.
+func countKey(key interface{}) interface{} {
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f != f {
+			return nanKey{v.Type()}
+		} else if f == 0 {
+			return reflect.Zero(v.Type()).Interface()
+		}
+	}
+	return key
+}
+
+// hash hashes a key returned by countKey
+func (c *accessCounter) hash(key interface{}) uint64 {
+	var bits uint64
+	switch v := reflect.ValueOf(key); v.Kind() {
+	case reflect.String:
+		return maphash.String(c.seed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits = uint64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		bits = v.Uint()
+	case reflect.Float32, reflect.Float64:
+		bits = math.Float64bits(v.Float())
+	default:
+		// A nanKey
+		bits = math.Float64bits(math.NaN())
+	}
+
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], bits)
+	return maphash.Bytes(c.seed, buf[:])
+}
+
+// newAccessCounter returns a counter tracking the topK most read keys
+func newAccessCounter(topK int) *accessCounter {
+	c := &accessCounter{
+		seed:  maphash.MakeSeed(),
+		width: max(1024, 64*topK),
+		topK:  topK,
+		index: make(map[interface{}]int, topK),
+	}
+	for i := range c.rows {
+		c.rows[i] = make([]uint64, c.width)
+	}
+	return c
+}
+
+// record counts one read of key, which must have passed checkKey
+func (c *accessCounter) record(key interface{}) {
+	// One hash yields every row index by double hashing.
+	ck := countKey(key)
+	h := c.hash(ck)
+	h1, h2 := h&0xffffffff, h>>32|1
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	estimate := ^uint64(0)
+	for i := range c.rows {
+		slot := &c.rows[i][(h1+uint64(i)*h2)%uint64(c.width)]
+		*slot++
+		estimate = min(estimate, *slot)
+	}
+
+	if i, ok := c.index[ck]; ok {
+		c.heap[i].Count = estimate
+		c.down(i)
+		return
+	}
+	if len(c.heap) < c.topK {
+		c.heap = append(c.heap, KeyCount{Key: key, Count: estimate})
+		c.index[ck] = len(c.heap) - 1
+		c.up(len(c.heap) - 1)
+		return
+	}
+	if estimate > c.heap[0].Count {
+		delete(c.index, countKey(c.heap[0].Key))
+		c.heap[0] = KeyCount{Key: key, Count: estimate}
+		c.index[ck] = 0
+		c.down(0)
+	}
+}
+
+// up restores the heap order from position i towards the root
+func (c *accessCounter) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if c.heap[parent].Count <= c.heap[i].Count {
+			return
+		}
+		c.swap(i, parent)
+		i = parent
+	}
+}
+
+// down restores the heap order from position i towards the leaves
+func (c *accessCounter) down(i int) {
+	for {
+		smallest := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(c.heap) && c.heap[child].Count < c.heap[smallest].Count {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		c.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// swap exchanges two heap entries and updates their positions
+func (c *accessCounter) swap(i, j int) {
+	c.heap[i], c.heap[j] = c.heap[j], c.heap[i]
+	c.index[countKey(c.heap[i].Key)] = i
+	c.index[countKey(c.heap[j].Key)] = j
+}
+
+// WithAccessCounting makes a SkipList count the reads by Get and Contains
+// that find their key and track the topK most read keys, reported by
+// HotKeys. Counts come from a count-min sketch of four rows, so they never
+// underestimate: with N reads in total, each count exceeds the true one by
+// at most e*N/w with probability above 98%, where w is the larger of 1024
+// and 64*topK. A key is tracked once its estimate beats the lowest tracked
+// one, so keys read about as often as the last tracked key may be swapped.
+// Every counted read takes a lock and hashes the key once.
+func WithAccessCounting(topK int) Option {
+	return func(o *options) error {
+		if topK < 1 {
+			return fmt.Errorf("Top K must be positive, got %d", topK)
+		}
+		o.accessTopK = topK
+		return nil
+	}
+}
+
+// HotKeys returns the most read keys with their approximate read counts,
+// most read first. It returns nothing unless the skip list was created
+// with WithAccessCounting.
+func (s *SkipList) HotKeys() []KeyCount {
+	if s.access == nil {
+		return nil
+	}
+
+	s.access.mu.Lock()
+	hot := append([]KeyCount(nil), s.access.heap...)
+	s.access.mu.Unlock()
+
+	sort.Slice(hot, func(i, j int) bool {
+		return hot[i].Count > hot[j].Count
+	})
+	return hot
+}
+
+// ResetAccessCounts forgets all reads counted so far
+func (s *SkipList) ResetAccessCounts() {
+	if s.access == nil {
+		return
+	}
+
+	s.access.mu.Lock()
+	defer s.access.mu.Unlock()
+	for i := range s.access.rows {
+		clear(s.access.rows[i])
+	}
+	s.access.heap = s.access.heap[:0]
+	clear(s.access.index)
+}
+
+// HotKeys returns the most read keys with their approximate read counts
+func (c *ConcurrentSkipList) HotKeys() []KeyCount {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.HotKeys()
+}
+
+// ResetAccessCounts forgets all reads counted so far
+func (c *ConcurrentSkipList) ResetAccessCounts() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.list.ResetAccessCounts()
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHotKeys(t *testing.T) {
+	s, err := New(String, WithAccessCounting(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		s.Insert(key, nil)
+	}
+	for key, reads := range map[string]int{"a": 5, "b": 1, "c": 3} {
+		for i := 0; i < reads; i++ {
+			s.Get(key)
+		}
+	}
+	s.Get("missing")
+
+	hot := s.HotKeys()
+	if len(hot) != 2 || hot[0] != (KeyCount{Key: "a", Count: 5}) || hot[1] != (KeyCount{Key: "c", Count: 3}) {
+		t.Fatalf("HotKeys() = %v, want [{a 5} {c 3}]", hot)
+	}
+	s.ResetAccessCounts()
+	if hot := s.HotKeys(); len(hot) != 0 {
+		t.Fatalf("HotKeys() = %v after ResetAccessCounts", hot)
+	}
+}
+
+func TestHotKeysFloatKeys(t *testing.T) {
+	s, err := New(Float64, WithAccessCounting(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []float64{math.NaN(), 0, 1} {
+		s.Insert(key, nil)
+	}
+
+	// NaN and -0 equal NaN and 0 in the list, so they share one counter each.
+	for i := 0; i < 3; i++ {
+		s.Get(math.NaN())
+	}
+	for _, key := range []float64{math.Copysign(0, -1), 0, math.Copysign(0, -1), 0} {
+		s.Contains(key)
+	}
+	s.Get(1.0)
+
+	hot := s.HotKeys()
+	if len(hot) != 3 {
+		t.Fatalf("HotKeys() = %v, want one entry per key", hot)
+	}
+	if key, ok := hot[0].Key.(float64); !ok || key != 0 || hot[0].Count != 4 {
+		t.Fatalf("HotKeys()[0] = %v, want 0 read 4 times", hot[0])
+	}
+	if key, ok := hot[1].Key.(float64); !ok || !math.IsNaN(key) || hot[1].Count != 3 {
+		t.Fatalf("HotKeys()[1] = %v, want NaN read 3 times", hot[1])
+	}
+
+	// A key of a type the list cannot order is rejected before it is hashed.
+	untyped, err := New(nil, WithAccessCounting(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := untyped.Get([]int{1}); ok {
+		t.Fatal("Get found a slice key")
+	}
+}
+
+// BenchmarkAccessCounting measures the cost WithAccessCounting adds to a
+// Get hit, against the same Get on a list without it
+func BenchmarkAccessCounting(b *testing.B) {
+	keys := make([]interface{}, 1024)
+	for i := range keys {
+		keys[i] = i
+	}
+	lists := map[string][]Option{
+		"Off": nil,
+		"On":  {WithAccessCounting(16)},
+	}
+
+	for _, name := range []string{"Off", "On"} {
+		s, err := New(Int, lists[name]...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, key := range keys {
+			s.Insert(key, key)
+		}
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, ok := s.Get(keys[i%len(keys)]); !ok {
+					b.Fatal("Get missed a key")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,512 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// KeyCodec converts keys to and from bytes for Encode and Decode
+type KeyCodec interface {
+	EncodeKey(key interface{}) ([]byte, error)
+	DecodeKey(data []byte) (interface{}, error)
+}
+
+// ValueCodec converts values to and from bytes for Encode and Decode
+type ValueCodec interface {
+	EncodeValue(value interface{}) ([]byte, error)
+	DecodeValue(data []byte) (interface{}, error)
+}
+
+// WithKeyCodec sets how Encode and Decode convert keys to bytes. Without
+// it keys are encoded natively, which covers every ordered key type.
+func WithKeyCodec(codec KeyCodec) Option {
+	return func(o *options) error {
+		if codec == nil {
+			return fmt.Errorf("Key codec cannot be nil")
+		}
+		o.keyCodec = codec
+		return nil
+	}
+}
+
+// WithValueCodec sets how Encode and Decode convert values to bytes.
+// Without it values are encoded natively, which covers nil, bool, []byte,
+// string and the integer and floating-point types; Encode fails on any
+// other value.
+func WithValueCodec(codec ValueCodec) Option {
+	return func(o *options) error {
+		if codec == nil {
+			return fmt.Errorf("Value codec cannot be nil")
+		}
+		o.valueCodec = codec
+		return nil
+	}
+}
+
+// binaryMagic starts every stream written by Encode
+var binaryMagic = [4]byte{'S', 'K', 'P', 'L'}
+
+// binaryVersion is the version of the format written by Encode. Version 2
+// added the structure section.
+//
+// Encode always writes the newest version, and Decode reads every version
+// from 1 up to it, so a stream written by an older release of this package
+// keeps loading after an upgrade. A stream of a newer version is rejected
+// with an UnsupportedVersionError instead of being misread.
+const binaryVersion = 2
+
+// SnapshotVersion returns the version of the format written by Encode and
+// EncodeStructure, which is the newest version Decode reads
+func SnapshotVersion() int {
+	return binaryVersion
+}
+
+// UnsupportedVersionError is returned by Decode for a stream whose format
+// version it cannot read. It matches ErrUnsupportedVersion and ErrInvalidStream.
+type UnsupportedVersionError struct {
+	Found     int // Version recorded in the stream
+	Supported int // Newest version Decode reads
+}
+
+// Error returns the message of the error
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("%v: version %d, want 1 to %d", ErrUnsupportedVersion, e.Found, e.Supported)
+}
+
+// Is reports whether target is ErrUnsupportedVersion or ErrInvalidStream
+func (e *UnsupportedVersionError) Is(target error) bool {
+	return target == ErrUnsupportedVersion || target == ErrInvalidStream
+}
+
+// Flags in the header of a stream
+const (
+	flagKeyCodec   = 1 << iota // Keys were written by a KeyCodec
+	flagValueCodec             // Values were written by a ValueCodec
+	flagStructure              // The entries are followed by the level of every node, since version 2
+)
+
+// MaxDecodeBlobSize is the largest key or value, in bytes, that Decode
+// accepts, so that a corrupt length cannot make it read without bound
+var MaxDecodeBlobSize = 64 << 20
+
+// binaryTypes lists the natively encoded types by tag; tag 0 is nil
+var binaryTypes = []reflect.Type{
+	nil,
+	reflect.TypeOf(int(0)), reflect.TypeOf(int8(0)), reflect.TypeOf(int16(0)),
+	reflect.TypeOf(int32(0)), reflect.TypeOf(int64(0)),
+	reflect.TypeOf(uint(0)), reflect.TypeOf(uint8(0)), reflect.TypeOf(uint16(0)),
+	reflect.TypeOf(uint32(0)), reflect.TypeOf(uint64(0)), reflect.TypeOf(uintptr(0)),
+	reflect.TypeOf(""), reflect.TypeOf(float64(0)), reflect.TypeOf(float32(0)),
+	reflect.TypeOf([]byte(nil)), reflect.TypeOf(false),
+}
+
+// binaryTag returns the tag of t and whether it is natively encoded
+func binaryTag(t reflect.Type) (byte, bool) {
+	for tag, bt := range binaryTypes {
+		if bt == t {
+			return byte(tag), true
+		}
+	}
+	return 0, false
+}
+
+// encodeNative appends the tag and the bytes of v to buf
+func encodeNative(buf []byte, v interface{}) ([]byte, error) {
+	tag, ok := binaryTag(reflect.TypeOf(v))
+	if !ok {
+		return nil, fmt.Errorf("Cannot encode %T without a codec", v)
+	}
+
+	var data []byte
+	switch v := v.(type) {
+	case nil:
+	case int:
+		data = binary.AppendVarint(nil, int64(v))
+	case int8:
+		data = binary.AppendVarint(nil, int64(v))
+	case int16:
+		data = binary.AppendVarint(nil, int64(v))
+	case int32:
+		data = binary.AppendVarint(nil, int64(v))
+	case int64:
+		data = binary.AppendVarint(nil, v)
+	case uint:
+		data = binary.AppendUvarint(nil, uint64(v))
+	case uint8:
+		data = binary.AppendUvarint(nil, uint64(v))
+	case uint16:
+		data = binary.AppendUvarint(nil, uint64(v))
+	case uint32:
+		data = binary.AppendUvarint(nil, uint64(v))
+	case uint64:
+		data = binary.AppendUvarint(nil, v)
+	case uintptr:
+		data = binary.AppendUvarint(nil, uint64(v))
+	case string:
+		data = []byte(v)
+	case float64:
+		data = binary.LittleEndian.AppendUint64(nil, math.Float64bits(v))
+	case float32:
+		data = binary.LittleEndian.AppendUint32(nil, math.Float32bits(v))
+	case []byte:
+		data = v
+	case bool:
+		if v {
+			data = []byte{1}
+		} else {
+			data = []byte{0}
+		}
+	}
+
+	buf = append(buf, tag)
+	return appendBlob(buf, data), nil
+}
+
+// decodeNative decodes the bytes of a value with the given tag
+func decodeNative(tag byte, data []byte) (interface{}, error) {
+	if int(tag) >= len(binaryTypes) {
+		return nil, fmt.Errorf("%w: unknown type tag %d", ErrInvalidStream, tag)
+	}
+	t := binaryTypes[tag]
+	if t == nil {
+		return nil, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, size := binary.Varint(data)
+		if size != len(data) || size <= 0 {
+			return nil, fmt.Errorf("%w: bad %v", ErrInvalidStream, t)
+		}
+		v := reflect.New(t).Elem()
+		if v.OverflowInt(n) {
+			return nil, fmt.Errorf("%w: %d overflows %v", ErrInvalidStream, n, t)
+		}
+		v.SetInt(n)
+		return v.Interface(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, size := binary.Uvarint(data)
+		if size != len(data) || size <= 0 {
+			return nil, fmt.Errorf("%w: bad %v", ErrInvalidStream, t)
+		}
+		v := reflect.New(t).Elem()
+		if v.OverflowUint(n) {
+			return nil, fmt.Errorf("%w: %d overflows %v", ErrInvalidStream, n, t)
+		}
+		v.SetUint(n)
+		return v.Interface(), nil
+	case reflect.String:
+		return string(data), nil
+	case reflect.Float64:
+		if len(data) != 8 {
+			return nil, fmt.Errorf("%w: bad float64", ErrInvalidStream)
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(data)), nil
+	case reflect.Float32:
+		if len(data) != 4 {
+			return nil, fmt.Errorf("%w: bad float32", ErrInvalidStream)
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(data)), nil
+	case reflect.Slice:
+		return data, nil
+	case reflect.Bool:
+		if len(data) != 1 || data[0] > 1 {
+			return nil, fmt.Errorf("%w: bad bool", ErrInvalidStream)
+		}
+		return data[0] == 1, nil
+	}
+	return nil, fmt.Errorf("%w: unknown type tag %d", ErrInvalidStream, tag)
+}
+
+// appendBlob appends data to buf prefixed with its length
+func appendBlob(buf, data []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+// Encode writes the skip list to w in a compact binary format: a header
+// with a magic number, the format version, the key type and the entry
+// count, followed by every entry in ascending key order as length-prefixed
+// key and value bytes. Keys and values are encoded natively unless a codec
+// was set with WithKeyCodec or WithValueCodec.
+func (s *SkipList) Encode(w io.Writer) error {
+	return s.encode(w, false)
+}
+
+// EncodeStructure writes the skip list to w like Encode, followed by a
+// structure section holding the level of every node in key order. Decode
+// then rebuilds the exact towers of s instead of drawing new levels, so
+// that a list can be captured and examined with the same shape elsewhere.
+func (s *SkipList) EncodeStructure(w io.Writer) error {
+	return s.encode(w, true)
+}
+
+// encode writes the skip list to w, with the structure section if structure is set
+func (s *SkipList) encode(w io.Writer, structure bool) error {
+	keyTag := byte(0)
+	if s.keyType != nil {
+		tag, ok := binaryTag(s.keyType)
+		if !ok {
+			return fmt.Errorf("Key type %v cannot be encoded", s.keyType)
+		}
+		keyTag = tag
+	}
+
+	keyCodec, valueCodec := s.list.opts.keyCodec, s.list.opts.valueCodec
+	flags := byte(0)
+	if keyCodec != nil {
+		flags |= flagKeyCodec
+	}
+	if valueCodec != nil {
+		flags |= flagValueCodec
+	}
+	if structure {
+		flags |= flagStructure
+	}
+
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, 0, 64<<10)
+	buf = append(buf, binaryMagic[:]...)
+	buf = append(buf, binaryVersion, keyTag, flags)
+	buf = binary.AppendUvarint(buf, uint64(s.list.length))
+
+	for n := s.list.head.forward[0]; n != nil; n = n.forward[0] {
+		var err error
+		if keyCodec != nil {
+			var data []byte
+			if data, err = keyCodec.EncodeKey(n.key); err == nil {
+				buf = appendBlob(buf, data)
+			}
+		} else {
+			buf, err = encodeNative(buf, n.key)
+		}
+		if err != nil {
+			return fmt.Errorf("Key %v: %w", n.key, err)
+		}
+
+		if valueCodec != nil {
+			var data []byte
+			if data, err = valueCodec.EncodeValue(n.value); err == nil {
+				buf = appendBlob(buf, data)
+			}
+		} else {
+			buf, err = encodeNative(buf, n.value)
+		}
+		if err != nil {
+			return fmt.Errorf("Value of key %v: %w", n.key, err)
+		}
+
+		if len(buf) >= 64<<10 {
+			if _, err := bw.Write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+	}
+
+	if structure {
+		for n := s.list.head.forward[0]; n != nil; n = n.forward[0] {
+			buf = binary.AppendUvarint(buf, uint64(len(n.forward)))
+			if len(buf) >= 64<<10 {
+				if _, err := bw.Write(buf); err != nil {
+					return err
+				}
+				buf = buf[:0]
+			}
+		}
+	}
+
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// binaryReader reads the parts of a stream, turning an early end of the
+// stream into ErrInvalidStream
+type binaryReader struct {
+	r *bufio.Reader // Source of the stream
+}
+
+// truncated wraps the error of a read that hit the end of the stream
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: truncated", ErrInvalidStream)
+	}
+	return err
+}
+
+// readByte reads a single byte
+func (br binaryReader) readByte() (byte, error) {
+	b, err := br.r.ReadByte()
+	return b, truncated(err)
+}
+
+// readUvarint reads a varint-encoded unsigned integer
+func (br binaryReader) readUvarint() (uint64, error) {
+	n, err := binary.ReadUvarint(br.r)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidStream, err)
+	}
+	return n, truncated(err)
+}
+
+// readBlob reads a length-prefixed byte string. The bytes are read as
+// they arrive, so a corrupt length fails on the truncated stream rather
+// than allocating up front.
+func (br binaryReader) readBlob() ([]byte, error) {
+	size, err := br.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if size > uint64(MaxDecodeBlobSize) {
+		return nil, fmt.Errorf("%w: length %d exceeds MaxDecodeBlobSize", ErrInvalidStream, size)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, br.r, int64(size)); err != nil {
+		return nil, truncated(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readNative reads a tagged, natively encoded key or value
+func (br binaryReader) readNative() (interface{}, error) {
+	tag, err := br.readByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := br.readBlob()
+	if err != nil {
+		return nil, err
+	}
+	return decodeNative(tag, data)
+}
+
+// Decode reads a skip list written by Encode and builds it with the
+// single-pass sorted load. The list takes the key type recorded in the
+// stream and the given options, which must include the codecs the stream
+// was written with. A stream written by EncodeStructure gives every node
+// its recorded level, which must not exceed the maximum level of the list.
+// Streams of every version up to SnapshotVersion are read; any other
+// version is rejected with an UnsupportedVersionError. A stream with the
+// wrong magic number, a truncated or corrupt body or keys out of order is
+// rejected with an error matching ErrInvalidStream or ErrNotSorted. A nil
+// key, or one not of the recorded key type, makes the body corrupt: its
+// error matches ErrInvalidStream as well as ErrNilKey or ErrKeyTypeMismatch.
+// Errors of the codecs are returned wrapped.
+func Decode(r io.Reader, opts ...Option) (*SkipList, error) {
+	br := binaryReader{r: bufio.NewReader(r)}
+
+	var header [7]byte
+	if _, err := io.ReadFull(br.r, header[:]); err != nil {
+		return nil, truncated(err)
+	}
+	if [4]byte(header[:4]) != binaryMagic {
+		return nil, fmt.Errorf("%w: bad magic number %q", ErrInvalidStream, header[:4])
+	}
+	if header[4] < 1 || header[4] > binaryVersion {
+		return nil, &UnsupportedVersionError{Found: int(header[4]), Supported: binaryVersion}
+	}
+	keyTag, flags := header[5], header[6]
+	known := byte(flagKeyCodec | flagValueCodec)
+	if header[4] >= 2 {
+		known |= flagStructure
+	}
+	if flags&^known != 0 {
+		return nil, fmt.Errorf("%w: unknown flags %#x", ErrInvalidStream, flags&^known)
+	}
+	if int(keyTag) >= len(binaryTypes) || binaryTypes[keyTag] != nil && orderedKeyTypes[binaryTypes[keyTag].String()] == nil {
+		return nil, fmt.Errorf("%w: bad key type tag %d", ErrInvalidStream, keyTag)
+	}
+
+	s, err := New(binaryTypes[keyTag], opts...)
+	if err != nil {
+		return nil, err
+	}
+	keyCodec, valueCodec := s.list.opts.keyCodec, s.list.opts.valueCodec
+	if flags&flagKeyCodec != 0 && keyCodec == nil {
+		return nil, fmt.Errorf("Stream was written with a key codec, but none was given")
+	}
+	if flags&flagValueCodec != 0 && valueCodec == nil {
+		return nil, fmt.Errorf("Stream was written with a value codec, but none was given")
+	}
+
+	count, err := br.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	// The count is not trusted for allocation; the slices grow as entries arrive.
+	keys := make([]interface{}, 0, min(count, 1<<16))
+	values := make([]interface{}, 0, min(count, 1<<16))
+	for i := uint64(0); i < count; i++ {
+		var key, value interface{}
+		if flags&flagKeyCodec != 0 {
+			var data []byte
+			if data, err = br.readBlob(); err == nil {
+				key, err = keyCodec.DecodeKey(data)
+			}
+		} else {
+			key, err = br.readNative()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Entry %d: %w", i, err)
+		}
+		if err := s.checkKey(key); err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %w", ErrInvalidStream, i, err)
+		}
+		if len(keys) > 0 && reflect.TypeOf(key) != reflect.TypeOf(keys[0]) {
+			return nil, fmt.Errorf("%w: entry %d: %w: expected %T, got %T", ErrInvalidStream, i, ErrKeyTypeMismatch, keys[0], key)
+		}
+
+		if flags&flagValueCodec != 0 {
+			var data []byte
+			if data, err = br.readBlob(); err == nil {
+				value, err = valueCodec.DecodeValue(data)
+			}
+		} else {
+			value, err = br.readNative()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Entry %d: %w", i, err)
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	if flags&flagStructure == 0 {
+		if err := s.BulkInsert(keys, values); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	levels := make([]int, len(keys))
+	for i := range levels {
+		level, err := br.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		if level < 1 || level > uint64(len(s.list.head.forward)) {
+			return nil, fmt.Errorf("%w: entry %d has level %d, want 1 to %d", ErrInvalidStream, i, level, len(s.list.head.forward))
+		}
+		levels[i] = int(level)
+	}
+	if err := s.list.checkSorted(keys); err != nil {
+		return nil, err
+	}
+	s.rec.recordBatch(keys, values)
+	s.list.loadSorted(keys, values, levels)
+	return s, nil
+}
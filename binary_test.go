@@ -0,0 +1,213 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestEncodeDecode100k(t *testing.T) {
+	const n = 100000
+	s := NewSkipList(Int)
+	rng := rand.New(rand.NewSource(1))
+	for _, key := range rng.Perm(n) {
+		s.Insert(key*3, strconv.Itoa(key))
+	}
+
+	var buf bytes.Buffer
+	if err := s.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify(t, decoded.list)
+	if decoded.Length() != n {
+		t.Fatalf("Length() = %d, want %d", decoded.Length(), n)
+	}
+	i := 0
+	for key, value := range decoded.All() {
+		if key != i*3 || value != strconv.Itoa(i) {
+			t.Fatalf("Entry %d = %v: %v, want %d: %q", i, key, value, i*3, strconv.Itoa(i))
+		}
+		i++
+	}
+}
+
+func TestDecodeRejectsBadKeys(t *testing.T) {
+	// A header for an Int list of one entry, followed by its key and a nil value
+	header := []byte{'S', 'K', 'P', 'L', binaryVersion, 1, 0, 1}
+	nilValue := []byte{0, 0}
+
+	tests := []struct {
+		name string
+		key  []byte
+		want error
+	}{
+		{"nil key", []byte{0, 0}, ErrNilKey},
+		{"mistyped key", []byte{12, 1, 'a'}, ErrKeyTypeMismatch},
+	}
+	for _, tt := range tests {
+		stream := append(append(append([]byte{}, header...), tt.key...), nilValue...)
+		_, err := Decode(bytes.NewReader(stream))
+		if !errors.Is(err, ErrInvalidStream) || !errors.Is(err, tt.want) {
+			t.Errorf("Decode of a %s = %v, want ErrInvalidStream and %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// FuzzDecode feeds arbitrary streams to Decode, seeded with valid ones.
+// Decode must either fail or return a well-formed list that encodes back
+// to an equivalent stream.
+func FuzzDecode(f *testing.F) {
+	for _, n := range []int{0, 1, 10, 100} {
+		s := NewSkipList(Int)
+		for i := 0; i < n; i++ {
+			s.Insert(i*7, strconv.Itoa(i))
+		}
+		var buf bytes.Buffer
+		if err := s.Encode(&buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+	f.Add([]byte("SKPL"))
+	f.Add([]byte{'S', 'K', 'P', 'L', binaryVersion, 1, 0, 0xff, 0xff, 0xff, 0xff, 0x0f})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		verify(t, s.list)
+
+		var buf bytes.Buffer
+		if err := s.Encode(&buf); err != nil {
+			t.Fatalf("Encode of a decoded list: %v", err)
+		}
+		again, err := Decode(&buf)
+		if err != nil {
+			t.Fatalf("Decode of a re-encoded list: %v", err)
+		}
+		if !reflect.DeepEqual(again.Entries(), s.Entries()) {
+			t.Fatal("Re-encoded list decodes to different entries")
+		}
+	})
+}
+
+func TestEncodeStructure(t *testing.T) {
+	s := NewSkipList(Int)
+	for _, key := range rand.New(rand.NewSource(2)).Perm(1000) {
+		s.Insert(key, strconv.Itoa(key))
+	}
+
+	var buf bytes.Buffer
+	if err := s.EncodeStructure(&buf); err != nil {
+		t.Fatal(err)
+	}
+	stream := buf.Bytes()
+	decoded, err := Decode(bytes.NewReader(stream))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify(t, decoded.list)
+	if decoded.list.level != s.list.level {
+		t.Errorf("Decoded level = %d, want %d", decoded.list.level, s.list.level)
+	}
+	for a, b := s.list.head.forward[0], decoded.list.head.forward[0]; a != nil || b != nil; a, b = a.forward[0], b.forward[0] {
+		if a == nil || b == nil {
+			t.Fatal("Decoded list has a different length")
+		}
+		if a.key != b.key || len(a.forward) != len(b.forward) {
+			t.Fatalf("Decoded node %v has %d levels, want node %v with %d", b.key, len(b.forward), a.key, len(a.forward))
+		}
+	}
+
+	// The towers must fit under the maximum level of the decoding list.
+	if _, err := Decode(bytes.NewReader(stream), WithMaxLevel(1)); !errors.Is(err, ErrInvalidStream) {
+		t.Errorf("Decode with a lower maximum level = %v, want ErrInvalidStream", err)
+	}
+
+	// The last byte is the level of the last node.
+	zero := append([]byte{}, stream...)
+	zero[len(zero)-1] = 0
+	if _, err := Decode(bytes.NewReader(zero)); !errors.Is(err, ErrInvalidStream) {
+		t.Errorf("Decode of a zero level = %v, want ErrInvalidStream", err)
+	}
+	if _, err := Decode(bytes.NewReader(stream[:len(stream)-1])); !errors.Is(err, ErrInvalidStream) {
+		t.Errorf("Decode of a truncated structure section = %v, want ErrInvalidStream", err)
+	}
+
+	unknown := append([]byte{}, stream...)
+	unknown[6] |= 0x80
+	if _, err := Decode(bytes.NewReader(unknown)); !errors.Is(err, ErrInvalidStream) {
+		t.Errorf("Decode with unknown flags = %v, want ErrInvalidStream", err)
+	}
+}
+
+func TestDecodeOlderVersions(t *testing.T) {
+	want := NewSkipList(Int)
+	for i := 0; i < 20; i++ {
+		want.Insert(i*5, "v"+string(rune('a'+i)))
+	}
+
+	// Each fixture was written by the Encode of its version and must keep
+	// decoding to the same list.
+	for version := 1; version <= SnapshotVersion(); version++ {
+		data, err := os.ReadFile(fmt.Sprintf("testdata/v%d.skpl", version))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Decode of version %d: %v", version, err)
+		}
+		verify(t, s.list)
+		if !reflect.DeepEqual(s.Entries(), want.Entries()) {
+			t.Errorf("Version %d decodes to %v, want %v", version, s.Entries(), want.Entries())
+		}
+
+		var buf bytes.Buffer
+		if err := s.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if got := int(buf.Bytes()[4]); got != SnapshotVersion() {
+			t.Errorf("Encode of a version %d list writes version %d, want %d", version, got, SnapshotVersion())
+		}
+	}
+
+	// Version 1 has no structure section.
+	v1, err := os.ReadFile("testdata/v1.skpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v1[6] |= flagStructure
+	if _, err := Decode(bytes.NewReader(v1)); !errors.Is(err, ErrInvalidStream) {
+		t.Errorf("Decode of version 1 with a structure section = %v, want ErrInvalidStream", err)
+	}
+}
+
+func TestDecodeUnsupportedVersion(t *testing.T) {
+	for _, version := range []byte{0, binaryVersion + 1, 0xff} {
+		stream := []byte{'S', 'K', 'P', 'L', version, 1, 0, 0}
+		_, err := Decode(bytes.NewReader(stream))
+		if !errors.Is(err, ErrUnsupportedVersion) || !errors.Is(err, ErrInvalidStream) {
+			t.Errorf("Decode of version %d = %v, want ErrUnsupportedVersion and ErrInvalidStream", version, err)
+		}
+		var verr *UnsupportedVersionError
+		if !errors.As(err, &verr) || verr.Found != int(version) || verr.Supported != SnapshotVersion() {
+			t.Errorf("Decode of version %d = %#v, want an UnsupportedVersionError{%d, %d}", version, err, version, SnapshotVersion())
+		}
+	}
+}
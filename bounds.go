@@ -0,0 +1,44 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CheckRange reports whether start and end are valid bounds for the range
+// methods of the skip list. A nil bound leaves that side of the range open.
+// A bound that does not match the key type yields ErrKeyTypeMismatch naming
+// both types, and a start greater than end yields ErrInvertedRange.
+//
+// Every range method validates its bounds the same way. Those returning an
+// error report the CheckRange error, and the others return an empty result.
+// Inverted bounds are only reported in strict mode; otherwise they select
+// no keys.
+func (s *SkipList) CheckRange(start, end interface{}) error {
+	for _, bound := range []interface{}{start, end} {
+		if bound == nil {
+			continue
+		}
+		if err := s.checkKey(bound); err != nil {
+			return err
+		}
+	}
+
+	if start != nil && end != nil && s.compare(start, end) > 0 {
+		return fmt.Errorf("%w: %v > %v", ErrInvertedRange, start, end)
+	}
+	return nil
+}
+
+// checkBounds validates the bounds of a range method with CheckRange,
+// letting inverted bounds through outside strict mode
+func (s *SkipList) checkBounds(start, end interface{}) error {
+	err := s.CheckRange(start, end)
+	if errors.Is(err, ErrInvertedRange) && !s.list.opts.strict {
+		return nil
+	}
+	return err
+}
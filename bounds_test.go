@@ -0,0 +1,147 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestBounds checks every range method against one table of bounds, in
+// lenient and in strict mode. The list holds the keys 0, 2, ..., 18.
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		start, end interface{}
+		want       []int // Keys selected, nil for none
+		err        error // Error of CheckRange, nil for valid bounds
+	}{
+		{nil, nil, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, nil},
+		{4, 10, []int{4, 6, 8, 10}, nil},
+		{3, 9, []int{4, 6, 8}, nil},
+		{nil, 5, []int{0, 2, 4}, nil},
+		{13, nil, []int{14, 16, 18}, nil},
+		{6, 6, []int{6}, nil},
+		{7, 7, nil, nil},
+		{-10, -1, nil, nil},
+		{19, 100, nil, nil},
+		{-100, 100, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, nil},
+		{10, 4, nil, ErrInvertedRange},
+		{"a", 10, nil, ErrKeyTypeMismatch},
+		{4, "z", nil, ErrKeyTypeMismatch},
+		{4.0, nil, nil, ErrKeyTypeMismatch},
+		{nil, int64(10), nil, ErrKeyTypeMismatch},
+	}
+
+	// Each method returns the keys it selected and its error, nil for
+	// methods without one; Range returns the Err of its iterator
+	methods := map[string]func(s *SkipList, start, end interface{}) ([]int, error){
+		"Range": func(s *SkipList, start, end interface{}) ([]int, error) {
+			var keys []int
+			it := s.Range(start, end)
+			for it.Next() {
+				keys = append(keys, it.Key().(int))
+			}
+			return keys, it.Err()
+		},
+		"RangeQuery": func(s *SkipList, start, end interface{}) ([]int, error) {
+			return entryKeys(s.RangeQuery(start, end)), nil
+		},
+		"RangeLimit": func(s *SkipList, start, end interface{}) ([]int, error) {
+			entries, _, err := s.RangeLimit(start, end, 100)
+			return entryKeys(entries), err
+		},
+		"ForEachInRange": func(s *SkipList, start, end interface{}) ([]int, error) {
+			var keys []int
+			s.ForEachInRange(start, end, func(key, value interface{}) bool {
+				keys = append(keys, key.(int))
+				return true
+			})
+			return keys, nil
+		},
+		"CountRange": func(s *SkipList, start, end interface{}) ([]int, error) {
+			n, err := s.CountRange(start, end)
+			return make([]int, n), err
+		},
+		"ExplainRange": func(s *SkipList, start, end interface{}) ([]int, error) {
+			e, err := s.ExplainRange(start, end)
+			return make([]int, e.Estimate), err
+		},
+		"DeleteRange": func(s *SkipList, start, end interface{}) ([]int, error) {
+			before := entryKeys(s.Entries())
+			n, err := s.DeleteRange(start, end)
+			after := make(map[int]bool)
+			for _, key := range entryKeys(s.Entries()) {
+				after[key] = true
+			}
+			var keys []int
+			for _, key := range before {
+				if !after[key] {
+					keys = append(keys, key)
+				}
+			}
+			if len(keys) != n {
+				return nil, errors.New("DeleteRange miscounted the removed keys")
+			}
+			return keys, err
+		},
+	}
+	// Methods that only count report as many zero keys as they counted.
+	counts := map[string]bool{"CountRange": true, "ExplainRange": true}
+	// Methods with an error result, which report the CheckRange error.
+	withErr := map[string]bool{"Range": true, "RangeLimit": true, "CountRange": true, "ExplainRange": true, "DeleteRange": true}
+
+	for _, strict := range []bool{false, true} {
+		for _, tt := range tests {
+			var opts []Option
+			if strict {
+				opts = append(opts, WithStrict())
+			}
+			s, err := New(Int, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 20; i += 2 {
+				s.Insert(i, i)
+			}
+
+			if err := s.CheckRange(tt.start, tt.end); !errors.Is(err, tt.err) || (err == nil) != (tt.err == nil) {
+				t.Errorf("CheckRange(%v, %v) = %v, want %v", tt.start, tt.end, err, tt.err)
+			}
+			wantErr := tt.err
+			if errors.Is(wantErr, ErrInvertedRange) && !strict {
+				wantErr = nil
+			}
+
+			for name, method := range methods {
+				s := s.Clone()
+				keys, err := method(s, tt.start, tt.end)
+				want := tt.want
+				if counts[name] {
+					want = make([]int, len(tt.want))
+				}
+				if len(keys) != 0 || len(want) != 0 {
+					if !reflect.DeepEqual(keys, want) {
+						t.Errorf("%s(%v, %v) with strict=%v selected %v, want %v", name, tt.start, tt.end, strict, keys, want)
+					}
+				}
+				if !withErr[name] {
+					continue
+				}
+				if !errors.Is(err, wantErr) || (err == nil) != (wantErr == nil) {
+					t.Errorf("%s(%v, %v) with strict=%v = %v, want %v", name, tt.start, tt.end, strict, err, wantErr)
+				}
+			}
+		}
+	}
+}
+
+// entryKeys returns the int keys of entries
+func entryKeys(entries []Entry) []int {
+	var keys []int
+	for _, e := range entries {
+		keys = append(keys, e.Key.(int))
+	}
+	return keys
+}
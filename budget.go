@@ -0,0 +1,69 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// budgetExceeded is the panic value that aborts an operation which ran out
+// of comparisons. Comparisons all happen before a mutation touches any
+// pointer, so unwinding from one leaves the list unchanged.
+type budgetExceeded struct{}
+
+// budget counts the comparisons of a single operation. Every operation gets
+// its own, so concurrent readers never share a counter.
+type budget struct {
+	limit int // Maximum number of comparisons
+	used  int // Comparisons made so far
+}
+
+// compare orders a and b as SkipList does, counting one comparison and
+// aborting the operation once the budget is exceeded
+func (b *budget) compare(x, y interface{}) int {
+	b.used++
+	if b.used > b.limit {
+		panic(budgetExceeded{})
+	}
+	return compareOrdered(x, y)
+}
+
+// withReadBudget runs fn, which must not modify the list, with the
+// comparison function it has to use: that of the list, or under
+// WithMaxComparisons one counting against a budget of its own. It returns
+// ErrBudgetExceeded if fn was aborted for exceeding the budget.
+func (s *SkipList) withReadBudget(fn func(compare func(a, b interface{}) int)) (err error) {
+	limit := s.list.opts.maxCompares
+	if limit <= 0 {
+		fn(s.list.compare)
+		return nil
+	}
+
+	b := &budget{limit: limit}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(budgetExceeded); !ok {
+				panic(r)
+			}
+			err = ErrBudgetExceeded
+		}
+	}()
+
+	fn(b.compare)
+	return nil
+}
+
+// withBudget runs fn with the comparison function of the list replaced by
+// a budgeted one for the duration of the call and returns ErrBudgetExceeded
+// if fn was aborted for exceeding it. The caller must have exclusive access
+// to the list, as every mutation does.
+func (s *SkipList) withBudget(fn func()) error {
+	if s.list.opts.maxCompares <= 0 {
+		fn()
+		return nil
+	}
+
+	return s.withReadBudget(func(compare func(a, b interface{}) int) {
+		saved := s.list.compare
+		s.list.compare = compare
+		defer func() { s.list.compare = saved }()
+		fn()
+	})
+}
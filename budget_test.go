@@ -0,0 +1,86 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestBudgetExceeded(t *testing.T) {
+	// A single level makes every lookup a linear scan, and inserting in
+	// descending order keeps each insert within the budget.
+	s, err := New(Int, WithMaxComparisons(10), WithMaxLevel(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 99; i >= 0; i-- {
+		if err := s.Insert(i, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Insert(50, "x"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Insert: got %v, want ErrBudgetExceeded", err)
+	}
+	if _, err := s.Search(50); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Search: got %v, want ErrBudgetExceeded", err)
+	}
+	if _, ok := s.Get(50); ok {
+		t.Fatal("Get over the budget reported a hit")
+	}
+	if err := s.Delete(50); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Delete: got %v, want ErrBudgetExceeded", err)
+	}
+	if s.Length() != 100 {
+		t.Fatalf("Length = %d after aborted operations, want 100", s.Length())
+	}
+	if err := s.CheckSorted(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestBudgetConcurrentReaders runs budgeted readers in parallel; under
+// -race it fails if they share budget state.
+func TestBudgetConcurrentReaders(t *testing.T) {
+	c, err := NewConcurrentSkipList(Int, WithMaxComparisons(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if err := c.Insert(i, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				key := (i*7 + g) % 1000
+				switch i % 3 {
+				case 0:
+					if v, ok := c.Get(key); !ok || v != key {
+						t.Errorf("Get(%d) = %v, %v", key, v, ok)
+						return
+					}
+				case 1:
+					if _, err := c.Search(key); err != nil {
+						t.Errorf("Search(%d): %v", key, err)
+						return
+					}
+				default:
+					if !c.Contains(key) {
+						t.Errorf("Contains(%d) = false", key)
+						return
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
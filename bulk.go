@@ -0,0 +1,188 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// checkSorted returns an error naming the first index whose key does not
+// sort strictly after the previous one
+func (l *List[K, V]) checkSorted(keys []K) error {
+	for i := 1; i < len(keys); i++ {
+		c := l.compare(keys[i-1], keys[i])
+		if c == 0 {
+			return fmt.Errorf("%w: duplicate key %v at index %d", ErrNotSorted, keys[i], i)
+		}
+		if c > 0 {
+			return fmt.Errorf("%w: key %v at index %d does not follow %v", ErrNotSorted, keys[i], i, keys[i-1])
+		}
+	}
+	return nil
+}
+
+// BulkInsert inserts keys[i] with values[i] for every i. The keys must be
+// strictly ascending; they may interleave with keys already in the list,
+// whose values are replaced when equal. Instead of descending from the head
+// for every key, a single left-to-right pass advances the rightmost node
+// before the current key at each level, and an empty list is built without
+// comparing keys at all. Nothing is inserted if the input is rejected.
+//
+// All comparisons happen in a first pass that records, for every key, how
+// many keys of the list sort before it; the nodes are then linked by rank
+// alone, so a panicking comparison function leaves the list as it was.
+func (l *List[K, V]) BulkInsert(keys []K, values []V) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("Got %d keys but %d values", len(keys), len(values))
+	}
+	if err := l.checkSorted(keys); err != nil {
+		return err
+	}
+	if l.length == 0 {
+		l.loadSorted(keys, values, nil)
+		return nil
+	}
+
+	update := make([]*node[K, V], len(l.head.forward))
+	rank := make([]int, len(l.head.forward))
+	for i := range update {
+		update[i] = l.head
+	}
+
+	less := make([]int, len(keys))
+	equal := make([]*node[K, V], len(keys))
+	for idx, key := range keys {
+		for i := l.level - 1; i >= 0; i-- {
+			// The frontier at level i is never behind the one above it.
+			if i < l.level-1 && rank[i+1] > rank[i] {
+				update[i], rank[i] = update[i+1], rank[i+1]
+			}
+			current := update[i]
+			for current.forward[i] != nil && l.compare(current.forward[i].key, key) < 0 {
+				rank[i] += current.span[i]
+				current = current.forward[i]
+			}
+			update[i] = current
+		}
+
+		less[idx] = rank[0]
+		if next := update[0].forward[0]; next != nil && l.compare(next.key, key) == 0 {
+			equal[idx] = next
+		}
+	}
+
+	for i := range update {
+		update[i], rank[i] = l.head, 0
+	}
+
+	inserted := 0
+	for idx, key := range keys {
+		if n := equal[idx]; n != nil {
+			n.value = values[idx]
+			l.order.touch(n)
+			continue
+		}
+
+		// Every key inserted so far sorts before key as well.
+		target := less[idx] + inserted
+		for i := l.level - 1; i >= 0; i-- {
+			if i < l.level-1 && rank[i+1] > rank[i] {
+				update[i], rank[i] = update[i+1], rank[i+1]
+			}
+			current := update[i]
+			for current.forward[i] != nil && rank[i]+current.span[i] <= target {
+				rank[i] += current.span[i]
+				current = current.forward[i]
+			}
+			update[i] = current
+		}
+
+		l.link(update, rank, key, values[idx])
+		inserted++
+	}
+
+	return nil
+}
+
+// loadSorted builds an empty list from strictly ascending keys, linking
+// each new node after the rightmost node of every level it reaches. The
+// node of keys[i] gets levels[i] levels, which must lie between 1 and the
+// maximum level, or a random level if levels is nil.
+func (l *List[K, V]) loadSorted(keys []K, values []V, levels []int) {
+	last := make([]*node[K, V], len(l.head.forward))
+	lastRank := make([]int, len(l.head.forward))
+	for i := range last {
+		last[i] = l.head
+	}
+
+	var prev *node[K, V]
+	for idx, key := range keys {
+		var level int
+		if levels != nil {
+			level = levels[idx]
+		} else {
+			level = l.randomLevel()
+		}
+		if level > l.level {
+			l.level = level
+		}
+
+		n := &node[K, V]{
+			key:      key,
+			value:    values[idx],
+			forward:  make([]*node[K, V], level),
+			span:     make([]int, level),
+			backward: prev,
+		}
+		for i := 0; i < level; i++ {
+			last[i].forward[i] = n
+			last[i].span[i] = idx + 1 - lastRank[i]
+			last[i], lastRank[i] = n, idx+1
+		}
+
+		l.order.push(n)
+		prev = n
+	}
+
+	for i := range last {
+		last[i].span[i] = len(keys) - lastRank[i]
+	}
+
+	l.tail = prev
+	l.length = len(keys)
+	l.mods++
+}
+
+// BulkInsert inserts keys[i] with values[i] for every i in a single pass.
+// The keys must be strictly ascending, or ErrNotSorted is returned; they
+// may interleave with keys already in the list, whose values are replaced
+// when equal. Nothing is inserted if any key is rejected.
+func (s *SkipList) BulkInsert(keys, values []interface{}) error {
+	if err := s.checkKeys(keys); err != nil {
+		return err
+	}
+	if err := s.list.BulkInsert(keys, values); err != nil {
+		return err
+	}
+
+	s.rec.recordBatch(keys, values)
+	return nil
+}
+
+// NewFromSorted creates a skip list holding keys[i] with values[i] for
+// every i. The keys must be strictly ascending; ErrNotSorted names the
+// first index that is out of order or duplicated. The levels are linked
+// left to right in a single pass without comparing keys against the list.
+func NewFromSorted(keyType reflect.Type, keys, values []interface{}, opts ...Option) (*SkipList, error) {
+	s, err := New(keyType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.BulkInsert(keys, values); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
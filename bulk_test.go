@@ -0,0 +1,187 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// randomKeys returns n distinct keys below limit in ascending order
+func randomKeys(rng *rand.Rand, n, limit int) []int {
+	keys := rng.Perm(limit)[:n]
+	slices.Sort(keys)
+	return keys
+}
+
+func TestBulkInsert(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 100; round++ {
+		l, _ := NewList[int, int](WithRandSource(rand.NewSource(int64(round))), WithInsertionOrder(true))
+		want := make(map[int]int)
+		for _, key := range randomKeys(rng, rng.Intn(100), 300) {
+			l.Insert(key, key)
+			want[key] = key
+		}
+
+		keys := randomKeys(rng, rng.Intn(100), 300)
+		values := make([]int, len(keys))
+		for i, key := range keys {
+			values[i] = -key
+			want[key] = -key
+		}
+		if err := l.BulkInsert(keys, values); err != nil {
+			t.Fatal(err)
+		}
+
+		verify(t, l)
+		if l.Len() != len(want) {
+			t.Fatalf("Len = %d, want %d", l.Len(), len(want))
+		}
+		for key, value := range want {
+			if got, ok := l.Get(key); !ok || got != value {
+				t.Fatalf("Get(%d) = %d, %v, want %d", key, got, ok, value)
+			}
+		}
+	}
+}
+
+func TestBulkInsertRejectsUnsorted(t *testing.T) {
+	l, _ := NewList[int, int]()
+	l.Insert(5, 5)
+	for _, keys := range [][]int{{1, 3, 2}, {1, 1}} {
+		if err := l.BulkInsert(keys, make([]int, len(keys))); !errors.Is(err, ErrNotSorted) {
+			t.Fatalf("BulkInsert(%v) = %v, want ErrNotSorted", keys, err)
+		}
+	}
+	if err := l.BulkInsert([]int{1}, nil); err == nil {
+		t.Fatal("BulkInsert accepted fewer values than keys")
+	}
+	if l.Len() != 1 {
+		t.Fatalf("Rejected input changed the list to %d keys", l.Len())
+	}
+}
+
+func TestBulkInsertComparatorPanic(t *testing.T) {
+	var armed bool
+	var after int
+	keys := []int{-1, 10, 11, 55, 120, 300}
+	for n := 0; n < 80; n++ {
+		l, _ := NewListFunc[int, int](panicky(&armed, &after), WithRandSource(rand.NewSource(int64(n))), WithInsertionOrder(false))
+		armed = false
+		for i := 0; i < 200; i += 2 {
+			l.Insert(i, i)
+		}
+
+		armed, after = true, n
+		panicked := mustPanic(func() { l.BulkInsert(keys, keys) })
+		armed = false
+
+		verify(t, l)
+		want := 100
+		if !panicked {
+			want = 104
+		}
+		if l.Len() != want {
+			t.Fatalf("BulkInsert with a panic after %d comparisons left %d keys, want %d", n, l.Len(), want)
+		}
+	}
+}
+
+func TestNewFromSorted(t *testing.T) {
+	keys := []interface{}{1, 2, 3, 5, 8}
+	values := []interface{}{"a", "b", "c", "d", "e"}
+	s, err := NewFromSorted(Int, keys, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify(t, s.list)
+	if got := s.KeysSlice(); !slices.Equal(got, keys) {
+		t.Fatalf("Keys = %v, want %v", got, keys)
+	}
+
+	if _, err := NewFromSorted(Int, []interface{}{2, 1}, []interface{}{nil, nil}); !errors.Is(err, ErrNotSorted) {
+		t.Fatalf("Unsorted keys: got %v, want ErrNotSorted", err)
+	}
+	if _, err := NewFromSorted(Int, []interface{}{1, "2"}, []interface{}{nil, nil}); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("Mismatched key: got %v, want ErrKeyTypeMismatch", err)
+	}
+}
+
+// sortedEntries returns n keys ascending in steps of step from start, with
+// their values
+func sortedEntries(n, start, step int) ([]interface{}, []interface{}) {
+	keys := make([]interface{}, n)
+	values := make([]interface{}, n)
+	for i := range keys {
+		keys[i] = start + i*step
+		values[i] = i
+	}
+	return keys, values
+}
+
+// BenchmarkBulkInsert merges 50k sorted keys into a list of 50k keys they
+// interleave with, in one BulkInsert or in a loop of Insert calls
+func BenchmarkBulkInsert(b *testing.B) {
+	const n = 50000
+	existing, _ := sortedEntries(n, 0, 2)
+	keys, values := sortedEntries(n, 1, 2)
+	setup := func() *SkipList {
+		s, err := NewFromSorted(Int, existing, make([]interface{}, n))
+		if err != nil {
+			b.Fatal(err)
+		}
+		return s
+	}
+
+	b.Run("BulkInsert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			s := setup()
+			b.StartTimer()
+			if err := s.BulkInsert(keys, values); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			s := setup()
+			b.StartTimer()
+			for j, key := range keys {
+				if err := s.Insert(key, values[j]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkNewFromSorted builds a list of 1M sorted keys in one pass or
+// with a loop of Insert calls
+func BenchmarkNewFromSorted(b *testing.B) {
+	const n = 1000000
+	keys, values := sortedEntries(n, 0, 1)
+
+	b.Run("NewFromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewFromSorted(Int, keys, values); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := NewSkipList(Int)
+			for j, key := range keys {
+				if err := s.Insert(key, values[j]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// compareAndSwap replaces the value of key with new if equal reports that
+// it matches old, and reports whether it did and whether key was present
+func (l *List[K, V]) compareAndSwap(key K, old, new V, equal func(a, b V) bool) (bool, bool) {
+	n := l.find(key)
+	if n == nil {
+		return false, false
+	}
+	if !equal(n.value, old) {
+		return false, true
+	}
+
+	n.value = new
+	l.order.touch(n)
+	return true, true
+}
+
+// compareAndDelete removes key if equal reports that its value matches
+// old, and reports whether it did and whether key was present
+func (l *List[K, V]) compareAndDelete(key K, old V, equal func(a, b V) bool) (bool, bool) {
+	update := make([]*node[K, V], l.level)
+	rank := make([]int, l.level)
+
+	current := l.path(key, update, rank)
+	if current == nil || l.compare(current.key, key) != 0 {
+		return false, false
+	}
+	if !equal(current.value, old) {
+		return false, true
+	}
+
+	l.unlink(update, current)
+	return true, true
+}
+
+// CompareAndSwapFunc replaces the value of key with new if equal reports
+// that the stored value matches old, and reports whether it did
+func (l *List[K, V]) CompareAndSwapFunc(key K, old, new V, equal func(a, b V) bool) bool {
+	swapped, _ := l.compareAndSwap(key, old, new, equal)
+	return swapped
+}
+
+// CompareAndDeleteFunc removes key if equal reports that its value matches
+// old, and reports whether it did
+func (l *List[K, V]) CompareAndDeleteFunc(key K, old V, equal func(a, b V) bool) bool {
+	deleted, _ := l.compareAndDelete(key, old, equal)
+	return deleted
+}
+
+// WithValueEqual sets the equality used by CompareAndSwap and
+// CompareAndDelete on a SkipList to compare stored values with the
+// expected ones. The default is reflect.DeepEqual, which never panics on
+// uncomparable values such as slices and maps.
+func WithValueEqual(equal func(a, b interface{}) bool) Option {
+	return func(o *options) error {
+		if equal == nil {
+			return fmt.Errorf("Value equality cannot be nil")
+		}
+		o.valueEqual = equal
+		return nil
+	}
+}
+
+// valueEqual returns the equality used to compare values
+func (s *SkipList) valueEqual() func(a, b interface{}) bool {
+	if s.list.opts.valueEqual != nil {
+		return s.list.opts.valueEqual
+	}
+	return reflect.DeepEqual
+}
+
+// CompareAndSwap replaces the value of key with new if the stored value
+// equals old, as with sync.Map.CompareAndSwap, and reports whether it did.
+// Values are compared with reflect.DeepEqual unless WithValueEqual is set.
+// The returned error matches ErrNilKey, ErrKeyTypeMismatch,
+// ErrBudgetExceeded or ErrKeyNotFound under errors.Is.
+func (s *SkipList) CompareAndSwap(key, old, new interface{}) (bool, error) {
+	if err := s.checkKey(key); err != nil {
+		return false, err
+	}
+
+	var swapped, found bool
+	if err := s.withBudget(func() {
+		swapped, found = s.list.compareAndSwap(key, old, new, s.valueEqual())
+	}); err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	if swapped {
+		s.rec.recordSwap(key, old, new)
+	}
+	return swapped, nil
+}
+
+// CompareAndDelete removes key if its value equals old, as with
+// sync.Map.CompareAndDelete, and reports whether it did. Values are
+// compared as by CompareAndSwap, and the errors are the same.
+func (s *SkipList) CompareAndDelete(key, old interface{}) (bool, error) {
+	if err := s.checkKey(key); err != nil {
+		return false, err
+	}
+
+	var deleted, found bool
+	if err := s.withBudget(func() {
+		deleted, found = s.list.compareAndDelete(key, old, s.valueEqual())
+	}); err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	if deleted {
+		s.rec.record(opCompareAndDelete, key, old)
+	}
+	return deleted, nil
+}
+
+// CompareAndSwap replaces the value of key with new if the stored value
+// equals old, and reports whether it did
+func (c *ConcurrentSkipList) CompareAndSwap(key, old, new interface{}) (bool, error) {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.CompareAndSwap(key, old, new)
+}
+
+// CompareAndDelete removes key if its value equals old, and reports whether it did
+func (c *ConcurrentSkipList) CompareAndDelete(key, old interface{}) (bool, error) {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.CompareAndDelete(key, old)
+}
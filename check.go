@@ -0,0 +1,48 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "fmt"
+
+// checkOrder panics if the key of a does not sort strictly before the key of b
+func (l *List[K, V]) checkOrder(a, b *node[K, V]) {
+	if l.compare(a.key, b.key) >= 0 {
+		panic(fmt.Sprintf("skiplist: iteration order violated: %v is followed by %v", a.key, b.key))
+	}
+}
+
+// next returns the node that follows n at level 0, under
+// WithIterationChecks after checking that it sorts after n
+func (l *List[K, V]) next(n *node[K, V]) *node[K, V] {
+	next := n.forward[0]
+	if next != nil && l.opts.checkOrder {
+		l.checkOrder(n, next)
+	}
+	return next
+}
+
+// CheckSorted verifies that the keys at every level are strictly increasing
+// and that level 0 holds Len() nodes. It walks the whole structure and is
+// meant for health checks rather than hot paths.
+func (l *List[K, V]) CheckSorted() error {
+	for i := l.level - 1; i >= 0; i-- {
+		count := 0
+		for current := l.head.forward[i]; current != nil; current = current.forward[i] {
+			if next := current.forward[i]; next != nil && l.compare(current.key, next.key) >= 0 {
+				return fmt.Errorf("Keys out of order at level %d: %v is followed by %v", i, current.key, next.key)
+			}
+			count++
+		}
+		if i == 0 && count != l.length {
+			return fmt.Errorf("Level 0 holds %d nodes but the length is %d", count, l.length)
+		}
+	}
+	return nil
+}
+
+// CheckSorted verifies that the keys at every level are strictly increasing
+// and that level 0 holds Length() nodes
+func (s *SkipList) CheckSorted() error {
+	return s.list.CheckSorted()
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "testing"
+
+// verify fails t unless every structural invariant of l holds: keys ascend
+// on every level, spans add up to ranks, backward links and the tail mirror
+// level 0, and the insertion order holds exactly the nodes of the list
+func verify[K any, V any](t testing.TB, l *List[K, V]) {
+	t.Helper()
+
+	if err := l.CheckSorted(); err != nil {
+		t.Fatal(err)
+	}
+
+	rank := make(map[*node[K, V]]int)
+	var prev *node[K, V]
+	for n := l.head.forward[0]; n != nil; n = n.forward[0] {
+		if n.backward != prev {
+			t.Fatalf("Backward link of %v does not point to its predecessor", n.key)
+		}
+		rank[n] = len(rank) + 1
+		prev = n
+	}
+	if l.tail != prev {
+		t.Fatal("Tail is not the last node of level 0")
+	}
+
+	for i := range l.head.forward {
+		if i >= l.level {
+			if l.head.forward[i] != nil {
+				t.Fatalf("Level %d is above the list level %d but not empty", i, l.level)
+			}
+			continue
+		}
+		r := 0
+		for n := l.head; n != nil; n = n.forward[i] {
+			want := l.length - r
+			if next := n.forward[i]; next != nil {
+				want = rank[next] - r
+				r = rank[next]
+			}
+			if n.span[i] != want {
+				t.Fatalf("Span at level %d after rank %d is %d, want %d", i, r, n.span[i], want)
+			}
+		}
+	}
+
+	if l.order != nil {
+		count := 0
+		var older *node[K, V]
+		for n := l.order.oldest; n != nil && count <= l.length; n = n.newer {
+			if _, ok := rank[n]; !ok {
+				t.Fatalf("Insertion order holds %v, which is not in the list", n.key)
+			}
+			if n.older != older {
+				t.Fatalf("Older link of %v does not point to the node inserted before", n.key)
+			}
+			older = n
+			count++
+		}
+		if count != l.length || l.order.newest != older {
+			t.Fatalf("Insertion order holds %d nodes, want %d", count, l.length)
+		}
+	}
+}
+
+// panicky returns a comparison function for ints that panics once armed
+// and called more than *after times
+func panicky(armed *bool, after *int) func(a, b int) int {
+	return func(a, b int) int {
+		if *armed {
+			if *after <= 0 {
+				panic("comparator failure")
+			}
+			*after--
+		}
+		return a - b
+	}
+}
+
+// mustPanic runs fn and reports whether it panicked
+func mustPanic(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return false
+}
+
+func TestIterationChecks(t *testing.T) {
+	l, err := NewList[int, int](WithIterationChecks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		l.Insert(i, i)
+	}
+	// Swap two keys in place so that level 0 runs ... 3, 5, 4, 6 ...
+	a := l.find(4)
+	b := l.find(5)
+	a.key, b.key = b.key, a.key
+
+	walks := map[string]func(){
+		"ForEach":        func() { l.ForEach(func(int, int) bool { return true }) },
+		"ForEachInRange": func() { l.ForEachInRange(0, 9, func(int, int) bool { return true }) },
+		"All": func() {
+			for range l.All() {
+			}
+		},
+		"Keys": func() {
+			for range l.Keys() {
+			}
+		},
+		"Values": func() {
+			for range l.Values() {
+			}
+		},
+		"Iterator": func() {
+			for it := l.Iterator(); it.Next(); {
+			}
+		},
+	}
+	for name, walk := range walks {
+		if !mustPanic(walk) {
+			t.Errorf("%s walked past keys out of order", name)
+		}
+	}
+}
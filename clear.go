@@ -0,0 +1,62 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// ClearIncremental empties the skip list like Clear, but spreads the cost of
+// releasing the old nodes over several calls. A call on a non-empty list
+// detaches its nodes at once, so the list is immediately empty and ready for
+// new inserts. Every call then releases up to maxPerCall detached nodes by
+// dropping their values and pointers, so the garbage collector can reclaim
+// them progressively, and returns the number still waiting. A maxPerCall
+// <= 0 releases them all. An iterator positioned on a released node stops.
+func (l *List[K, V]) ClearIncremental(maxPerCall int) int {
+	if first := l.head.forward[0]; first != nil {
+		if l.pending == nil {
+			l.pending = first
+		} else {
+			l.pendingTail.forward[0] = first
+		}
+		l.pendingTail = l.tail
+		l.pendingLen += l.length
+
+		l.head.forward = make([]*node[K, V], l.opts.maxLevel)
+		l.head.span = make([]int, l.opts.maxLevel)
+		l.tail = nil
+		l.level = 1
+		l.length = 0
+		l.mods++
+		l.order.reset()
+	}
+
+	for released := 0; l.pending != nil && (maxPerCall <= 0 || released < maxPerCall); released++ {
+		n := l.pending
+		l.pending = n.forward[0]
+
+		var zero V
+		n.value = zero
+		clear(n.forward)
+		n.backward = nil
+		l.pendingLen--
+	}
+	if l.pending == nil {
+		l.pendingTail = nil
+	}
+
+	return l.pendingLen
+}
+
+// ClearIncremental empties the skip list at once and releases up to
+// maxPerCall of the old nodes per call, returning the number still waiting.
+// A maxPerCall <= 0 releases them all.
+func (s *SkipList) ClearIncremental(maxPerCall int) int {
+	return s.list.ClearIncremental(maxPerCall)
+}
+
+// ClearIncremental empties the skip list at once and releases up to
+// maxPerCall of the old nodes per call, returning the number still waiting
+func (c *ConcurrentSkipList) ClearIncremental(maxPerCall int) int {
+	c.lockFor(func() *keyRange { return wholeRange })
+	defer c.mu.Unlock()
+	return c.list.ClearIncremental(maxPerCall)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "testing"
+
+func TestClearIncremental(t *testing.T) {
+	l, _ := NewList[int, *int]()
+	for i := 0; i < 1000; i++ {
+		l.Insert(i, &i)
+	}
+	first := l.head.forward[0]
+	it := l.Iterator()
+	it.Next()
+
+	if remaining := l.ClearIncremental(100); remaining != 900 {
+		t.Fatalf("First ClearIncremental(100) left %d nodes, want 900", remaining)
+	}
+	verify(t, l)
+	if l.Len() != 0 {
+		t.Fatalf("Len() = %d right after ClearIncremental, want 0", l.Len())
+	}
+	if first.value != nil || first.forward[0] != nil {
+		t.Fatal("The first released node still holds its value or pointers")
+	}
+	if it.Next() {
+		t.Fatal("An iterator on a released node moved on")
+	}
+
+	// The list takes new keys while old nodes wait to be released.
+	for i := 0; i < 50; i++ {
+		v := -i
+		l.Insert(i*2, &v)
+	}
+	verify(t, l)
+	if v, ok := l.Get(10); !ok || *v != -5 {
+		t.Fatalf("Get(10) = %v, %t while old nodes are pending, want -5", v, ok)
+	}
+	if l.Len() != 50 {
+		t.Fatalf("Len() = %d after 50 inserts, want 50", l.Len())
+	}
+
+	// A call on a non-empty list detaches its nodes too.
+	if remaining := l.ClearIncremental(100); remaining != 850 {
+		t.Fatalf("ClearIncremental(100) left %d nodes, want 850", remaining)
+	}
+	if l.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", l.Len())
+	}
+	if remaining := l.ClearIncremental(0); remaining != 0 {
+		t.Fatalf("ClearIncremental(0) left %d nodes, want 0", remaining)
+	}
+	if remaining := l.ClearIncremental(10); remaining != 0 {
+		t.Fatalf("ClearIncremental of an empty list left %d nodes", remaining)
+	}
+
+	l.Insert(1, nil)
+	verify(t, l)
+}
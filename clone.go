@@ -0,0 +1,139 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "math/rand"
+
+// clone returns a copy of the skip list ordered by compare, with values
+// passed through copyValue unless it is nil. Every node is rebuilt with the
+// same tower height and spans as the original, so the copy has the same
+// shape and shares no pointers with it.
+func (l *List[K, V]) clone(compare func(a, b K) int, copyValue func(V) V) *List[K, V] {
+	head := &node[K, V]{
+		forward: make([]*node[K, V], len(l.head.forward)),
+		span:    append([]int(nil), l.head.span...),
+	}
+
+	last := make([]*node[K, V], len(head.forward))
+	for i := range last {
+		last[i] = head
+	}
+
+	// copies maps original nodes to their copies to rebuild the insertion order.
+	var copies map[*node[K, V]]*node[K, V]
+	if l.order != nil {
+		copies = make(map[*node[K, V]]*node[K, V], l.length)
+	}
+
+	var prev *node[K, V]
+	for n := l.head.forward[0]; n != nil; n = n.forward[0] {
+		c := &node[K, V]{
+			key:      n.key,
+			value:    n.value,
+			forward:  make([]*node[K, V], len(n.forward)),
+			span:     append([]int(nil), n.span...),
+			backward: prev,
+		}
+		if copyValue != nil {
+			c.value = copyValue(n.value)
+		}
+		for i := range c.forward {
+			last[i].forward[i] = c
+			last[i] = c
+		}
+		if copies != nil {
+			copies[n] = c
+		}
+		prev = c
+	}
+
+	c := &List[K, V]{
+		head:    head,
+		tail:    prev,
+		level:   l.level,
+		length:  l.length,
+		compare: compare,
+		opts:    l.opts,
+		// Seeded from the original so that a seeded list clones deterministically.
+		rng: rand.New(rand.NewSource(l.rng.Int63())),
+	}
+	if l.order != nil {
+		c.order = newInsertionOrder[K, V](l.order.moveOnOverwrite)
+		for n := l.order.oldest; n != nil; n = n.newer {
+			c.order.push(copies[n])
+		}
+	}
+	return c
+}
+
+// valueCloner returns the function set with WithValueCloner, typed for
+// the values of the list, or nil if there is none
+func (l *List[K, V]) valueCloner() func(V) V {
+	fn := l.opts.valueCloner
+	if fn == nil {
+		return nil
+	}
+	return func(v V) V {
+		c := fn(v)
+		if c == nil {
+			var zero V
+			return zero
+		}
+		return c.(V)
+	}
+}
+
+// Clone returns an independent copy of the skip list with the same
+// comparison function and options. Keys are copied as is, and values too
+// unless WithValueCloner was given.
+func (l *List[K, V]) Clone() *List[K, V] {
+	return l.clone(l.compare, l.valueCloner())
+}
+
+// CloneWith returns an independent copy of the skip list like Clone, with
+// every value replaced by copyValue(value), e.g. to deep-copy values
+func (l *List[K, V]) CloneWith(copyValue func(V) V) *List[K, V] {
+	return l.clone(l.compare, copyValue)
+}
+
+// Clone returns an independent copy of the skip list with the same key
+// type and options. Keys are copied as is, and values too unless
+// WithValueCloner was given.
+func (s *SkipList) Clone() *SkipList {
+	return s.clone(s.list.valueCloner())
+}
+
+// CloneWith returns an independent copy of the skip list like Clone, with
+// every value replaced by copyValue(value), e.g. to deep-copy values
+func (s *SkipList) CloneWith(copyValue func(interface{}) interface{}) *SkipList {
+	return s.clone(copyValue)
+}
+
+// clone returns a copy of s with values passed through copyValue unless it is nil
+func (s *SkipList) clone(copyValue func(interface{}) interface{}) *SkipList {
+	c := &SkipList{
+		keyType: s.keyType,
+	}
+	c.list = s.list.clone(c.compare, copyValue)
+	if s.access != nil {
+		c.access = newAccessCounter(s.access.topK)
+	}
+	return c
+}
+
+// Clone returns an independent copy of the skip list
+func (c *ConcurrentSkipList) Clone() *ConcurrentSkipList {
+	// The exclusive lock is needed because cloning draws from the generator.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &ConcurrentSkipList{list: c.list.Clone()}
+}
+
+// CloneWith returns an independent copy of the skip list with every value
+// replaced by copyValue(value)
+func (c *ConcurrentSkipList) CloneWith(copyValue func(interface{}) interface{}) *ConcurrentSkipList {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &ConcurrentSkipList{list: c.list.CloneWith(copyValue)}
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCloneIndependent(t *testing.T) {
+	s, _ := New(Int, WithProbability(0.25), WithMaxLevel(12), WithRandSource(rand.NewSource(1)))
+	for i := 0; i < 300; i++ {
+		s.Insert(i, i)
+	}
+	want := s.Entries()
+
+	c := s.Clone()
+	verify(t, c.list)
+	if c.keyType != s.keyType || c.list.level != s.list.level || c.Length() != s.Length() || c.list.opts.probability != 0.25 || c.list.opts.maxLevel != 12 {
+		t.Fatal("Clone did not keep the key type, level, length and options")
+	}
+	for a, b := s.list.head.forward[0], c.list.head.forward[0]; a != nil; a, b = a.forward[0], b.forward[0] {
+		if a == b || len(a.forward) != len(b.forward) {
+			t.Fatalf("Clone shares node %v or changes its height", a.key)
+		}
+	}
+
+	for i := 300; i < 400; i++ {
+		c.Insert(i, i)
+	}
+	for i := 0; i < 100; i++ {
+		c.Delete(i)
+	}
+	c.Insert(150, -1)
+
+	verify(t, s.list)
+	verify(t, c.list)
+	if !reflect.DeepEqual(s.Entries(), want) {
+		t.Fatal("Writes to the clone changed the original")
+	}
+	if c.Length() != 300 {
+		t.Fatalf("Clone has %d keys, want 300", c.Length())
+	}
+	if err := c.Insert("a", 0); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("Insert of a string into a clone of an Int list = %v, want ErrKeyTypeMismatch", err)
+	}
+}
+
+func TestValueCloner(t *testing.T) {
+	cloneSlice := func(v interface{}) interface{} {
+		return slices.Clone(v.([]int))
+	}
+	s, err := New(Int, WithValueCloner(cloneSlice))
+	if err != nil {
+		t.Fatal(err)
+	}
+	held := make(map[int][]int)
+	for i := 0; i < 50; i++ {
+		held[i] = []int{i}
+		s.Insert(i, held[i])
+	}
+
+	clone := s.Clone()
+	snapshot := s.SnapshotIterator()
+	frozen := s.Compile()
+	for _, value := range held {
+		value[0] = -1
+	}
+
+	verify(t, clone.list)
+	for i := 0; i < 50; i++ {
+		if got, _ := clone.Get(i); got.([]int)[0] != i {
+			t.Fatalf("Clone holds %v under %d after the original value changed, want [%d]", got, i, i)
+		}
+		if got, _ := frozen.Get(i); got.([]int)[0] != i {
+			t.Fatalf("Compile holds %v under %d after the original value changed, want [%d]", got, i, i)
+		}
+	}
+	for i := 0; snapshot.Next(); i++ {
+		if got := snapshot.Value().([]int); got[0] != i {
+			t.Fatalf("SnapshotIterator yields %v under %d after the original value changed, want [%d]", got, i, i)
+		}
+	}
+
+	// Split moves the values out of the list and clones them on the way.
+	before, _ := clone.Get(30)
+	r, err := clone.Split(25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify(t, r.list)
+	before.([]int)[0] = -1
+	if got, _ := r.Get(30); got.([]int)[0] != 30 {
+		t.Fatalf("Split holds %v under 30 after the value it moved changed, want [30]", got)
+	}
+
+	// CloneWith replaces the cloner, and lists without one share values.
+	shared := s.CloneWith(func(v interface{}) interface{} { return v })
+	if got, _ := shared.Get(0); got.([]int)[0] != -1 {
+		t.Errorf("CloneWith holds %v under 0, want the shared [-1]", got)
+	}
+	plain := NewSkipList(Int)
+	plain.Insert(0, held[0])
+	held[0][0] = 7
+	if got, _ := plain.Clone().Get(0); got.([]int)[0] != 7 {
+		t.Errorf("Clone without a cloner holds %v under 0, want the shared [7]", got)
+	}
+}
+
+func TestValueClonerTyped(t *testing.T) {
+	l, err := NewList[string, []byte](WithValueCloner(func(v interface{}) interface{} {
+		if v == nil {
+			return nil
+		}
+		return slices.Clone(v.([]byte))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := []byte("abc")
+	l.Insert("a", value)
+	l.Insert("b", nil)
+
+	c := l.Clone()
+	value[0] = 'x'
+	if got, _ := c.Get("a"); string(got) != "abc" {
+		t.Errorf("Clone holds %q, want %q", got, "abc")
+	}
+	if got, ok := c.Get("b"); !ok || got != nil {
+		t.Errorf("Clone holds %q, %t under b, want nil, true", got, ok)
+	}
+
+	if _, err := NewList[int, int](WithValueCloner(nil)); err == nil {
+		t.Error("WithValueCloner(nil) succeeded")
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ConcurrentSkipList is a skip list that is safe for concurrent use by
+// multiple goroutines. Mutations take an exclusive lock, after waiting for
+// any range held through LockRange that covers their keys, and reads share
+// a read lock.
+type ConcurrentSkipList struct {
+	mu     sync.RWMutex // Guards list
+	list   *SkipList    // Underlying skip list
+	ranges rangeLocks   // Key ranges held through LockRange
+}
+
+// ConcurrentIterator is the iterator for a ConcurrentSkipList. It holds the
+// read lock of the skip list from creation until Close is called, so writers
+// block while it is open; it must not be held by a goroutine that writes to
+// the same list.
+type ConcurrentIterator struct {
+	*SkipListIterator
+	mu     *sync.RWMutex // Read-locked while the iterator is open
+	closed bool          // Whether Close has been called
+}
+
+// NewConcurrentSkipList creates a new concurrent skip list with the specified key type and options
+func NewConcurrentSkipList(keyType reflect.Type, opts ...Option) (*ConcurrentSkipList, error) {
+	list, err := New(keyType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ConcurrentSkipList{list: list}, nil
+}
+
+// Insert inserts a new key-value pair into the skip list
+func (c *ConcurrentSkipList) Insert(key, value interface{}) error {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.Insert(key, value)
+}
+
+// GetOrInsert returns the existing value for key if present. Otherwise it
+// inserts value and returns it. The lookup and the insert happen under
+// the same lock.
+func (c *ConcurrentSkipList) GetOrInsert(key, value interface{}) (interface{}, bool, error) {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.GetOrInsert(key, value)
+}
+
+// InsertIfAbsent inserts a new key-value pair unless the key is already
+// present and reports whether the pair was inserted
+func (c *ConcurrentSkipList) InsertIfAbsent(key, value interface{}) (bool, error) {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.InsertIfAbsent(key, value)
+}
+
+// Upsert inserts a new key-value pair into the skip list, replacing the
+// value if the key is already present, and reports whether a node was created
+func (c *ConcurrentSkipList) Upsert(key, value interface{}) (bool, error) {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.Upsert(key, value)
+}
+
+// Replace replaces the value stored under key, which must already be present
+func (c *ConcurrentSkipList) Replace(key, value interface{}) error {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.Replace(key, value)
+}
+
+// Search searches for a key in the skip list and returns the corresponding value
+func (c *ConcurrentSkipList) Search(key interface{}) (interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Search(key)
+}
+
+// Get returns the value stored under key and whether the key was found
+func (c *ConcurrentSkipList) Get(key interface{}) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Get(key)
+}
+
+// Contains reports whether key is present in the skip list
+func (c *ConcurrentSkipList) Contains(key interface{}) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Contains(key)
+}
+
+// Delete deletes a key from the skip list
+func (c *ConcurrentSkipList) Delete(key interface{}) error {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.Delete(key)
+}
+
+// Update replaces the value stored under key with fn applied to it. The
+// write lock is held for the whole read-modify-write, so fn must not call
+// back into the skip list.
+func (c *ConcurrentSkipList) Update(key interface{}, fn func(old interface{}) interface{}) error {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.Update(key, fn)
+}
+
+// Remove deletes a key from the skip list and returns the value it held
+// and whether anything was removed
+func (c *ConcurrentSkipList) Remove(key interface{}) (interface{}, bool) {
+	c.lockKey(key)
+	defer c.mu.Unlock()
+	return c.list.Remove(key)
+}
+
+// Length returns the length of the skip list
+func (c *ConcurrentSkipList) Length() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Length()
+}
+
+// Clear removes all elements from the skip list
+func (c *ConcurrentSkipList) Clear() {
+	c.lockFor(func() *keyRange { return wholeRange })
+	defer c.mu.Unlock()
+	c.list.Clear()
+}
+
+// First returns the smallest key in the skip list, with its value
+func (c *ConcurrentSkipList) First() (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.First()
+}
+
+// Last returns the largest key in the skip list, with its value
+func (c *ConcurrentSkipList) Last() (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Last()
+}
+
+// Min returns the smallest key in the skip list, with its value
+func (c *ConcurrentSkipList) Min() (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Min()
+}
+
+// Max returns the largest key in the skip list, with its value
+func (c *ConcurrentSkipList) Max() (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Max()
+}
+
+// Floor returns the largest key less than or equal to key, with its value
+func (c *ConcurrentSkipList) Floor(key interface{}) (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Floor(key)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, with its value
+func (c *ConcurrentSkipList) Ceiling(key interface{}) (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Ceiling(key)
+}
+
+// Lower returns the largest key strictly less than key, with its value
+func (c *ConcurrentSkipList) Lower(key interface{}) (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Lower(key)
+}
+
+// Higher returns the smallest key strictly greater than key, with its value
+func (c *ConcurrentSkipList) Higher(key interface{}) (interface{}, interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Higher(key)
+}
+
+// RangeLimit returns at most limit entries with start <= key <= end in
+// ascending key order, and the key to resume from
+func (c *ConcurrentSkipList) RangeLimit(start, end interface{}, limit int) ([]Entry, interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.RangeLimit(start, end, limit)
+}
+
+// Iterator returns a new iterator for the skip list. The iterator holds the
+// read lock until Close is called.
+func (c *ConcurrentSkipList) Iterator() *ConcurrentIterator {
+	c.mu.RLock()
+	return &ConcurrentIterator{
+		SkipListIterator: c.list.Iterator(),
+		mu:               &c.mu,
+	}
+}
+
+// Close releases the read lock held by the iterator. It is safe to call
+// Close more than once.
+func (it *ConcurrentIterator) Close() {
+	if !it.closed {
+		it.closed = true
+		it.mu.RUnlock()
+	}
+}
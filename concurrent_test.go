@@ -0,0 +1,125 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentReadersWriters mixes writers and readers on one list; run
+// it under -race. Each writer owns the keys congruent to its index, so its
+// own model tells what the list must hold for them at the end.
+func TestConcurrentReadersWriters(t *testing.T) {
+	const (
+		writers = 4
+		readers = 8
+		keys    = 512
+		ops     = 1000
+	)
+	c, err := NewConcurrentSkipList(Int)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	models := make([]map[int]int, writers)
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		models[w] = make(map[int]int)
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(w)))
+			model := models[w]
+			for i := 0; i < ops; i++ {
+				key := rng.Intn(keys/writers)*writers + w
+				_, present := model[key]
+				switch rng.Intn(4) {
+				case 0:
+					if _, ok := c.Remove(key); ok != present {
+						t.Errorf("Remove(%d) disagreed with the model", key)
+						return
+					}
+					delete(model, key)
+				case 1:
+					if err := c.Update(key, func(old interface{}) interface{} { return old.(int) + 1 }); (err == nil) != present {
+						t.Errorf("Update(%d) = %v, model has key: %v", key, err, present)
+						return
+					}
+					if present {
+						model[key]++
+					}
+				default:
+					if _, err := c.Upsert(key, i); err != nil {
+						t.Errorf("Upsert(%d): %v", key, err)
+						return
+					}
+					model[key] = i
+				}
+			}
+		}(w)
+	}
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(writers + r)))
+			for i := 0; i < ops; i++ {
+				key := rng.Intn(keys)
+				switch rng.Intn(16) {
+				case 0:
+					c.Get(key)
+				case 1:
+					c.Contains(key)
+				case 2:
+					if k, _, ok := c.Floor(key); ok && k.(int) > key {
+						t.Errorf("Floor(%d) = %v", key, k)
+						return
+					}
+				case 3:
+					if k, _, ok := c.Ceiling(key); ok && k.(int) < key {
+						t.Errorf("Ceiling(%d) = %v", key, k)
+						return
+					}
+				case 4:
+					it := c.Iterator()
+					last := -1
+					for it.Next() {
+						if k := it.Key().(int); k <= last {
+							t.Errorf("Iterator yielded %d after %d", k, last)
+						} else {
+							last = k
+						}
+					}
+					it.Close()
+				default:
+					c.Search(key)
+				case 5:
+					c.ForEachInRange(key, nil, func(k, value interface{}) bool {
+						if k.(int) < key {
+							t.Errorf("ForEachInRange(%d, nil) yielded %v", key, k)
+							return false
+						}
+						return true
+					})
+				}
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	want := 0
+	for _, model := range models {
+		for key, value := range model {
+			if got, ok := c.Get(key); !ok || got != value {
+				t.Fatalf("Get(%d) = %v, %v, want %d, true", key, got, ok, value)
+			}
+		}
+		want += len(model)
+	}
+	if c.Length() != want {
+		t.Fatalf("Length() = %d, want %d", c.Length(), want)
+	}
+}
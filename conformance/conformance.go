@@ -0,0 +1,370 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package conformance checks that an ordered map implementation behaves
+// like the skip list in the parent package.
+//
+// Run drives an implementation through a fixed battery of operation
+// sequences covering ordering, overwrite semantics, deletion, range
+// boundaries, the smallest and largest keys, writes during iteration and
+// error values, so alternative backends can be verified to be drop-in
+// replacements:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, func() conformance.Map {
+//			return newMyBTree()
+//		})
+//	}
+package conformance
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	skiplist "github.com/qishenonly/SkipList"
+)
+
+// Map is the behaviour exercised by the suite. The suite only uses int keys.
+type Map interface {
+	// Insert stores value under key, replacing any previous value.
+	// A nil key must fail with skiplist.ErrNilKey.
+	Insert(key, value interface{}) error
+	// Get returns the value stored under key and whether it was found.
+	Get(key interface{}) (interface{}, bool)
+	// Delete removes key. A missing key must fail with skiplist.ErrKeyNotFound
+	// and a nil key with skiplist.ErrNilKey.
+	Delete(key interface{}) error
+	// Length returns the number of keys stored.
+	Length() int
+	// Min returns the smallest key with its value, and false if the map is empty.
+	Min() (key, value interface{}, ok bool)
+	// Max returns the largest key with its value, and false if the map is empty.
+	Max() (key, value interface{}, ok bool)
+	// Ascend calls fn for every key in [min, max] in ascending order until
+	// fn returns false. A nil bound leaves that side of the range open.
+	//
+	// fn may insert and delete keys. Keys inserted ahead of the current one
+	// are visited and keys deleted ahead of it are not; keys inserted behind
+	// it are not visited. If fn deletes the current key, Ascend goes on
+	// with the key that followed it.
+	Ascend(min, max interface{}, fn func(key, value interface{}) bool)
+}
+
+// skipListMap adapts a *skiplist.SkipList to Map
+type skipListMap struct {
+	*skiplist.SkipList
+}
+
+// FromSkipList adapts a skip list from the parent package to Map
+func FromSkipList(s *skiplist.SkipList) Map {
+	return skipListMap{s}
+}
+
+// Ascend calls fn for every key in [min, max] in ascending order
+func (m skipListMap) Ascend(min, max interface{}, fn func(key, value interface{}) bool) {
+	it := m.Range(min, max)
+	for it.Next() {
+		if !fn(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
+// Run runs the conformance suite against the maps returned by newMap. Each
+// subtest calls newMap once and expects an empty map.
+func Run(t *testing.T, newMap func() Map) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, m Map)
+	}{
+		{"Empty", testEmpty},
+		{"Ordering", testOrdering},
+		{"Overwrite", testOverwrite},
+		{"Delete", testDelete},
+		{"NilValue", testNilValue},
+		{"Range", testRange},
+		{"EarlyStop", testEarlyStop},
+		{"MinMax", testMinMax},
+		{"WriteDuringAscend", testWriteDuringAscend},
+		{"Errors", testErrors},
+		{"RandomOperations", testRandomOperations},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newMap())
+		})
+	}
+}
+
+// keys collects the keys in [min, max] in iteration order
+func keys(m Map, min, max interface{}) []int {
+	var result []int
+	m.Ascend(min, max, func(key, value interface{}) bool {
+		result = append(result, key.(int))
+		return true
+	})
+	return result
+}
+
+func mustInsert(t *testing.T, m Map, key, value interface{}) {
+	t.Helper()
+	if err := m.Insert(key, value); err != nil {
+		t.Fatalf("Insert(%v) = %v", key, err)
+	}
+}
+
+func testEmpty(t *testing.T, m Map) {
+	if n := m.Length(); n != 0 {
+		t.Fatalf("Length() = %d, want 0", n)
+	}
+	if _, ok := m.Get(1); ok {
+		t.Fatalf("Get(1) found a key in an empty map")
+	}
+	if got := keys(m, nil, nil); len(got) != 0 {
+		t.Fatalf("Ascend yielded %v on an empty map", got)
+	}
+}
+
+func testOrdering(t *testing.T, m Map) {
+	input := []int{5, 3, 9, 1, 7, 2, 8, 4, 6, 0}
+	for _, k := range input {
+		mustInsert(t, m, k, k*10)
+	}
+
+	want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if got := keys(m, nil, nil); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ascend yielded %v, want %v", got, want)
+	}
+	for _, k := range input {
+		if v, ok := m.Get(k); !ok || v != k*10 {
+			t.Fatalf("Get(%d) = %v, %v, want %d, true", k, v, ok, k*10)
+		}
+	}
+}
+
+func testOverwrite(t *testing.T, m Map) {
+	mustInsert(t, m, 1, "a")
+	mustInsert(t, m, 1, "b")
+
+	if n := m.Length(); n != 1 {
+		t.Fatalf("Length() = %d after overwrite, want 1", n)
+	}
+	if v, ok := m.Get(1); !ok || v != "b" {
+		t.Fatalf("Get(1) = %v, %v, want b, true", v, ok)
+	}
+}
+
+func testDelete(t *testing.T, m Map) {
+	for k := 0; k < 10; k++ {
+		mustInsert(t, m, k, k)
+	}
+	for _, k := range []int{0, 9, 5} {
+		if err := m.Delete(k); err != nil {
+			t.Fatalf("Delete(%d) = %v", k, err)
+		}
+		if _, ok := m.Get(k); ok {
+			t.Fatalf("Get(%d) found a deleted key", k)
+		}
+	}
+
+	want := []int{1, 2, 3, 4, 6, 7, 8}
+	if got := keys(m, nil, nil); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ascend yielded %v, want %v", got, want)
+	}
+	if n := m.Length(); n != len(want) {
+		t.Fatalf("Length() = %d, want %d", n, len(want))
+	}
+
+	for _, k := range want {
+		if err := m.Delete(k); err != nil {
+			t.Fatalf("Delete(%d) = %v", k, err)
+		}
+	}
+	if n := m.Length(); n != 0 {
+		t.Fatalf("Length() = %d after deleting every key, want 0", n)
+	}
+	mustInsert(t, m, 42, 42)
+	if got := keys(m, nil, nil); !reflect.DeepEqual(got, []int{42}) {
+		t.Fatalf("Ascend yielded %v after reuse, want [42]", got)
+	}
+}
+
+func testNilValue(t *testing.T, m Map) {
+	mustInsert(t, m, 1, nil)
+
+	if v, ok := m.Get(1); !ok || v != nil {
+		t.Fatalf("Get(1) = %v, %v, want <nil>, true", v, ok)
+	}
+	if n := m.Length(); n != 1 {
+		t.Fatalf("Length() = %d, want 1", n)
+	}
+}
+
+func testRange(t *testing.T, m Map) {
+	for k := 0; k < 100; k += 10 {
+		mustInsert(t, m, k, k)
+	}
+
+	tests := []struct {
+		min, max interface{}
+		want     []int
+	}{
+		{nil, nil, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}},
+		{15, 45, []int{20, 30, 40}},
+		{20, 40, []int{20, 30, 40}},
+		{nil, 20, []int{0, 10, 20}},
+		{80, nil, []int{80, 90}},
+		{50, 50, []int{50}},
+		{51, 59, nil},
+		{60, 40, nil},
+		{-20, -10, nil},
+		{100, 200, nil},
+		{-100, 1000, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}},
+	}
+
+	for _, tt := range tests {
+		if got := keys(m, tt.min, tt.max); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Ascend(%v, %v) yielded %v, want %v", tt.min, tt.max, got, tt.want)
+		}
+	}
+}
+
+func testEarlyStop(t *testing.T, m Map) {
+	for k := 0; k < 10; k++ {
+		mustInsert(t, m, k, k)
+	}
+
+	calls := 0
+	m.Ascend(nil, nil, func(key, value interface{}) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Fatalf("Ascend called fn %d times after it returned false, want 1", calls)
+	}
+}
+
+func testMinMax(t *testing.T, m Map) {
+	if key, _, ok := m.Min(); ok {
+		t.Fatalf("Min() found %v in an empty map", key)
+	}
+	if key, _, ok := m.Max(); ok {
+		t.Fatalf("Max() found %v in an empty map", key)
+	}
+
+	for _, k := range []int{5, 3, 9, 1, 7} {
+		mustInsert(t, m, k, k*10)
+	}
+	check := func(wantMin, wantMax int) {
+		t.Helper()
+		if key, value, ok := m.Min(); !ok || key != wantMin || value != wantMin*10 {
+			t.Fatalf("Min() = %v, %v, %v, want %d, %d, true", key, value, ok, wantMin, wantMin*10)
+		}
+		if key, value, ok := m.Max(); !ok || key != wantMax || value != wantMax*10 {
+			t.Fatalf("Max() = %v, %v, %v, want %d, %d, true", key, value, ok, wantMax, wantMax*10)
+		}
+	}
+	check(1, 9)
+
+	mustInsert(t, m, 0, 0)
+	mustInsert(t, m, 10, 100)
+	check(0, 10)
+
+	for _, k := range []int{0, 10, 1} {
+		if err := m.Delete(k); err != nil {
+			t.Fatalf("Delete(%d) = %v", k, err)
+		}
+	}
+	check(3, 9)
+}
+
+func testWriteDuringAscend(t *testing.T, m Map) {
+	for k := 0; k < 100; k += 10 {
+		mustInsert(t, m, k, k)
+	}
+
+	var got []int
+	m.Ascend(nil, nil, func(key, value interface{}) bool {
+		got = append(got, key.(int))
+		if key == 20 {
+			mustInsert(t, m, 25, 25)
+			mustInsert(t, m, 5, 5)
+			if err := m.Delete(40); err != nil {
+				t.Fatalf("Delete(40) = %v", err)
+			}
+			if err := m.Delete(20); err != nil {
+				t.Fatalf("Delete(20) = %v", err)
+			}
+		}
+		return true
+	})
+
+	want := []int{0, 10, 20, 25, 30, 50, 60, 70, 80, 90}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ascend yielded %v while writing, want %v", got, want)
+	}
+	want = []int{0, 5, 10, 25, 30, 50, 60, 70, 80, 90}
+	if got := keys(m, nil, nil); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ascend yielded %v afterwards, want %v", got, want)
+	}
+}
+
+func testErrors(t *testing.T, m Map) {
+	if err := m.Insert(nil, 1); !errors.Is(err, skiplist.ErrNilKey) {
+		t.Errorf("Insert(nil) = %v, want ErrNilKey", err)
+	}
+	if err := m.Delete(nil); !errors.Is(err, skiplist.ErrNilKey) {
+		t.Errorf("Delete(nil) = %v, want ErrNilKey", err)
+	}
+	if err := m.Delete(1); !errors.Is(err, skiplist.ErrKeyNotFound) {
+		t.Errorf("Delete(missing) = %v, want ErrKeyNotFound", err)
+	}
+	if _, ok := m.Get(nil); ok {
+		t.Errorf("Get(nil) reported a key")
+	}
+	if n := m.Length(); n != 0 {
+		t.Errorf("Length() = %d after failed operations, want 0", n)
+	}
+}
+
+func testRandomOperations(t *testing.T, m Map) {
+	r := rand.New(rand.NewSource(1))
+	model := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(500)
+		switch r.Intn(3) {
+		case 0:
+			err := m.Delete(k)
+			if _, ok := model[k]; ok != (err == nil) {
+				t.Fatalf("Delete(%d) = %v, model has key: %v", k, err, ok)
+			}
+			delete(model, k)
+		default:
+			mustInsert(t, m, k, i)
+			model[k] = i
+		}
+	}
+
+	want := make([]int, 0, len(model))
+	for k := range model {
+		want = append(want, k)
+	}
+	sort.Ints(want)
+
+	if got := keys(m, nil, nil); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ascend yielded %d keys, want %d", len(got), len(want))
+	}
+	if n := m.Length(); n != len(want) {
+		t.Fatalf("Length() = %d, want %d", n, len(want))
+	}
+	for k, v := range model {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) = %v, %v, want %d, true", k, got, ok, v)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package conformance_test
+
+import (
+	"testing"
+
+	skiplist "github.com/qishenonly/SkipList"
+	"github.com/qishenonly/SkipList/conformance"
+)
+
+// concurrentMap adapts a *skiplist.ConcurrentSkipList to conformance.Map
+type concurrentMap struct {
+	*skiplist.ConcurrentSkipList
+}
+
+// Ascend calls fn for every key in [min, max] in ascending order. Each step
+// is a separate Ceiling or Higher, so fn may write to the list, which
+// ForEachInRange does not allow.
+func (m concurrentMap) Ascend(min, max interface{}, fn func(key, value interface{}) bool) {
+	key, value, ok := m.First()
+	if min != nil {
+		key, value, ok = m.Ceiling(min)
+	}
+	for ok && (max == nil || key.(int) <= max.(int)) {
+		if !fn(key, value) {
+			return
+		}
+		key, value, ok = m.Higher(key)
+	}
+}
+
+func TestSkipList(t *testing.T) {
+	conformance.Run(t, func() conformance.Map {
+		return conformance.FromSkipList(skiplist.NewSkipList(skiplist.Int))
+	})
+}
+
+func TestConcurrentSkipList(t *testing.T) {
+	conformance.Run(t, func() conformance.Map {
+		c, err := skiplist.NewConcurrentSkipList(skiplist.Int)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return concurrentMap{c}
+	})
+}
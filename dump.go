@@ -0,0 +1,92 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultDumpLimit is the number of nodes shown by String, and by Dump
+// when it is called with a limit <= 0
+var DefaultDumpLimit = 32
+
+// Dump writes the length and level of the skip list to w, then one line
+// per level from the top down. Each node is a column holding its key on
+// every level its tower reaches and dashes where that level passes over
+// it, e.g.
+//
+//	length=25 level=3
+//	L2: - 5 - - 11
+//	L1: - 5 7 - 11
+//	L0: 3 5 7 9 11 ... (+20 more)
+//
+// Only the first limit nodes are shown; a limit <= 0 means DefaultDumpLimit.
+func (l *List[K, V]) Dump(w io.Writer, limit int) error {
+	if limit <= 0 {
+		limit = DefaultDumpLimit
+	}
+
+	var labels []string
+	column := make(map[*node[K, V]]int)
+	for n := l.head.forward[0]; n != nil && len(labels) < limit; n = n.forward[0] {
+		column[n] = len(labels)
+		labels = append(labels, fmt.Sprint(n.key))
+	}
+
+	if _, err := fmt.Fprintf(w, "length=%d level=%d\n", l.length, l.level); err != nil {
+		return err
+	}
+
+	var line strings.Builder
+	for i := l.level - 1; i >= 0; i-- {
+		line.Reset()
+		fmt.Fprintf(&line, "L%d:", i)
+
+		next := 0
+		for n := l.head.forward[i]; n != nil; n = n.forward[i] {
+			col, ok := column[n]
+			if !ok {
+				break
+			}
+			for ; next < col; next++ {
+				line.WriteString(" " + strings.Repeat("-", len(labels[next])))
+			}
+			line.WriteString(" " + labels[col])
+			next = col + 1
+		}
+
+		if i == 0 && l.length > len(labels) {
+			fmt.Fprintf(&line, " ... (+%d more)", l.length-len(labels))
+		}
+		line.WriteByte('\n')
+
+		if _, err := io.WriteString(w, line.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// String renders the structure of the skip list as written by Dump with
+// DefaultDumpLimit, for debugging
+func (l *List[K, V]) String() string {
+	var b strings.Builder
+	l.Dump(&b, 0)
+	return b.String()
+}
+
+// Dump writes the structure of the skip list to w, one line per level,
+// showing at most limit nodes. See List.Dump for the format.
+func (s *SkipList) Dump(w io.Writer, limit int) error {
+	return s.list.Dump(w, limit)
+}
+
+// String renders the structure of the skip list for debugging, showing at
+// most DefaultDumpLimit nodes
+func (s *SkipList) String() string {
+	return s.list.String()
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// EqualFunc reports whether l and other hold the same keys, by the
+// comparison function of l, with values that equal reports as equal.
+// Lists of different lengths are unequal without walking either.
+func (l *List[K, V]) EqualFunc(other *List[K, V], equal func(a, b V) bool) bool {
+	if l == other {
+		return true
+	}
+	if other == nil || l.length != other.length {
+		return false
+	}
+
+	a, b := l.head.forward[0], other.head.forward[0]
+	for a != nil && b != nil {
+		if l.compare(a.key, b.key) != 0 || !equal(a.value, b.value) {
+			return false
+		}
+		a, b = a.forward[0], b.forward[0]
+	}
+
+	return a == nil && b == nil
+}
+
+// Equal reports whether s and other have the same key type and hold the
+// same key-value pairs. Values are compared with the equality set by
+// WithValueEqual on s, reflect.DeepEqual by default.
+func (s *SkipList) Equal(other *SkipList) bool {
+	return s.EqualFunc(other, s.valueEqual())
+}
+
+// EqualFunc reports whether s and other have the same key type and hold
+// the same keys, with values that equal reports as equal
+func (s *SkipList) EqualFunc(other *SkipList, equal func(a, b interface{}) bool) bool {
+	if other == nil || s.keyType != other.keyType {
+		return false
+	}
+	return s.list.EqualFunc(other.list, equal)
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "errors"
+
+var (
+	// ErrNilKey is returned when a nil key is passed to the skip list
+	ErrNilKey = errors.New("Key cannot be nil")
+
+	// ErrKeyNotFound is returned when a key is not present in the skip list
+	ErrKeyNotFound = errors.New("Key not found")
+
+	// ErrKeyTypeMismatch is returned when a key does not have the key type of the skip list
+	ErrKeyTypeMismatch = errors.New("Key type mismatch")
+
+	// ErrUnorderedKeyType is returned when a key type that cannot be ordered,
+	// such as a slice or struct type, is given to New or used as a key
+	ErrUnorderedKeyType = errors.New("Key type is not ordered")
+
+	// ErrRankOutOfRange is returned when a rank is outside the skip list
+	ErrRankOutOfRange = errors.New("Rank out of range")
+
+	// ErrNotSorted is returned when keys given as sorted input are not strictly ascending
+	ErrNotSorted = errors.New("Keys are not in strictly ascending order")
+
+	// ErrBudgetExceeded is returned when an operation needs more comparisons
+	// than allowed by WithMaxComparisons; the skip list is left unchanged
+	ErrBudgetExceeded = errors.New("Comparison budget exceeded")
+
+	// ErrDuplicateKey is returned in strict mode when a key is inserted twice
+	ErrDuplicateKey = errors.New("Key already exists")
+
+	// ErrInvertedRange is returned in strict mode when the start of a range is greater than its end
+	ErrInvertedRange = errors.New("Range start is greater than range end")
+
+	// ErrSeekPastEnd is reported by SkipListIterator.Err in strict mode when
+	// Seek finds no key at or after the one sought within the iterator's bounds
+	ErrSeekPastEnd = errors.New("Seek key is past the last key")
+
+	// ErrNotReconfigurable is returned when an option cannot be changed on a live skip list
+	ErrNotReconfigurable = errors.New("Option cannot be changed on a live skip list")
+
+	// ErrInvalidStream is returned when Decode reads a stream that was not
+	// written by Encode, is truncated or is corrupt
+	ErrInvalidStream = errors.New("Invalid skip list stream")
+
+	// ErrUnsupportedVersion is matched by the UnsupportedVersionError that
+	// Decode returns for a stream of a format version it cannot read
+	ErrUnsupportedVersion = errors.New("Unsupported stream version")
+
+	// ErrKeyOutOfRange is returned when a key outside a locked range is written through its RangeLock
+	ErrKeyOutOfRange = errors.New("Key is outside the locked range")
+
+	// ErrRangeUnlocked is returned when a RangeLock is used after Unlock
+	ErrRangeUnlocked = errors.New("Range is no longer locked")
+)
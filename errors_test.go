@@ -0,0 +1,80 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	s := NewSkipList(Int)
+	s.Insert(1, "one")
+
+	// Each method is called with a nil key, a key of the wrong type and a
+	// missing key, and must report the matching sentinel.
+	methods := map[string]func(key interface{}) error{
+		"Insert": func(key interface{}) error { return s.Insert(key, 0) },
+		"Search": func(key interface{}) error {
+			_, err := s.Search(key)
+			return err
+		},
+		"Delete":  s.Delete,
+		"Replace": func(key interface{}) error { return s.Replace(key, 0) },
+		"Update": func(key interface{}) error {
+			return s.Update(key, func(old interface{}) interface{} { return old })
+		},
+		"Upsert": func(key interface{}) error {
+			_, err := s.Upsert(key, 0)
+			return err
+		},
+		"InsertIfAbsent": func(key interface{}) error {
+			_, err := s.InsertIfAbsent(key, 0)
+			return err
+		},
+		"GetOrInsert": func(key interface{}) error {
+			_, _, err := s.GetOrInsert(key, 0)
+			return err
+		},
+		"Rank": func(key interface{}) error {
+			_, err := s.Rank(key)
+			return err
+		},
+		"CompareAndSwap": func(key interface{}) error {
+			_, err := s.CompareAndSwap(key, "one", "uno")
+			return err
+		},
+		"CompareAndDelete": func(key interface{}) error {
+			_, err := s.CompareAndDelete(key, "one")
+			return err
+		},
+	}
+	// Methods that insert missing keys have no not-found error.
+	inserts := map[string]bool{"Insert": true, "Upsert": true, "InsertIfAbsent": true, "GetOrInsert": true}
+
+	for name, method := range methods {
+		if err := method(nil); !errors.Is(err, ErrNilKey) {
+			t.Errorf("%s(nil) = %v, want ErrNilKey", name, err)
+		}
+		if err := method("1"); !errors.Is(err, ErrKeyTypeMismatch) {
+			t.Errorf("%s(\"1\") = %v, want ErrKeyTypeMismatch", name, err)
+		}
+		if inserts[name] {
+			continue
+		}
+		if err := method(2); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("%s(missing) = %v, want ErrKeyNotFound", name, err)
+		}
+	}
+
+	// The sentinels are distinct, so errors.Is tells them apart.
+	sentinels := []error{ErrNilKey, ErrKeyNotFound, ErrKeyTypeMismatch}
+	for i, a := range sentinels {
+		for j, b := range sentinels {
+			if (i == j) != errors.Is(a, b) {
+				t.Errorf("errors.Is(%v, %v) = %v", a, b, i != j)
+			}
+		}
+	}
+}
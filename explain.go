@@ -0,0 +1,58 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// Explain describes how a range read over the skip list is carried out
+type Explain struct {
+	Levels      int   // Number of levels descended by the seek to the start of the range
+	Steps       []int // Forward pointers followed at each level during the seek, top level first
+	Estimate    int   // Number of keys in the range, computed from spans
+	ReachesTail bool  // Whether the range extends to the last key, which is found through the tail pointer
+}
+
+// explain seeks to the lower bound of a range while recording the steps
+// taken at each level, then counts the keys in the range from spans
+func (l *List[K, V]) explain(min K, hasMin bool, max K, hasMax bool) Explain {
+	e := Explain{
+		Levels: l.level,
+		Steps:  make([]int, l.level),
+	}
+
+	// The seek to min, counting the keys before it.
+	current := l.head
+	below := 0
+	for i := l.level - 1; i >= 0; i-- {
+		for hasMin && current.forward[i] != nil && l.compare(current.forward[i].key, min) < 0 {
+			below += current.span[i]
+			current = current.forward[i]
+			e.Steps[l.level-1-i]++
+		}
+	}
+
+	upTo := l.length
+	if hasMax {
+		upTo = l.countUpTo(max)
+	}
+
+	if upTo > below {
+		e.Estimate = upTo - below
+		e.ReachesTail = upTo == l.length
+	}
+
+	return e
+}
+
+// ExplainRange describes the read of the keys with start <= key <= end
+// without returning them: the steps the seek to start takes at each level,
+// the number of keys in the range and whether the range reaches the tail.
+// A nil bound leaves that side of the range open. The skip list keeps no
+// finger or bloom filter, so the tail pointer is the only shortcut reported.
+// Bounds are checked as by RangeLimit.
+func (s *SkipList) ExplainRange(start, end interface{}) (Explain, error) {
+	if err := s.checkBounds(start, end); err != nil {
+		return Explain{}, err
+	}
+
+	return s.list.explain(start, start != nil, end, end != nil), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+//go:build skiplist_cshared
+
+// Command cshared exports the ffi façade as a C shared library:
+//
+//	go build -tags skiplist_cshared -buildmode=c-shared -o libskiplist.so ./ffi/cshared
+//
+// Keys and values cross the boundary as pointer and length pairs, with
+// lengths of at most 2^31-1 bytes; longer ones yield ErrInvalidLength. Buffers
+// returned to C are allocated with malloc and must be released with
+// SkipListFree. A NULL range bound leaves that side of the range open.
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"math"
+	"unsafe"
+
+	"github.com/qishenonly/SkipList/ffi"
+)
+
+// goBytes copies a C buffer into Go memory, mapping NULL to nil. It reports
+// false for a length that C.GoBytes cannot take, which includes negative
+// lengths converted to size_t, and for a NULL buffer with a length.
+func goBytes(p unsafe.Pointer, n C.size_t) ([]byte, bool) {
+	if n > math.MaxInt32 {
+		return nil, false
+	}
+	if p == nil {
+		return nil, n == 0
+	}
+	return C.GoBytes(p, C.int(n)), true
+}
+
+// cBytes copies b into a malloc'ed buffer owned by the caller
+func cBytes(b []byte, p *unsafe.Pointer, n *C.size_t) {
+	*p = C.CBytes(b)
+	*n = C.size_t(len(b))
+}
+
+//export SkipListNew
+func SkipListNew(h *C.uintptr_t) C.int32_t {
+	handle, errno := ffi.NewHandle()
+	*h = C.uintptr_t(handle)
+	return C.int32_t(errno)
+}
+
+//export SkipListClose
+func SkipListClose(h C.uintptr_t) C.int32_t {
+	return C.int32_t(ffi.Close(uintptr(h)))
+}
+
+//export SkipListPut
+func SkipListPut(h C.uintptr_t, k unsafe.Pointer, klen C.size_t, v unsafe.Pointer, vlen C.size_t) C.int32_t {
+	key, ok := goBytes(k, klen)
+	if !ok {
+		return C.int32_t(ffi.ErrInvalidLength)
+	}
+	value, ok := goBytes(v, vlen)
+	if !ok {
+		return C.int32_t(ffi.ErrInvalidLength)
+	}
+	return C.int32_t(ffi.HandlePut(uintptr(h), key, value))
+}
+
+//export SkipListGet
+func SkipListGet(h C.uintptr_t, k unsafe.Pointer, klen C.size_t, v *unsafe.Pointer, vlen *C.size_t) C.int32_t {
+	key, ok := goBytes(k, klen)
+	if !ok {
+		return C.int32_t(ffi.ErrInvalidLength)
+	}
+	value, errno := ffi.HandleGet(uintptr(h), key)
+	if errno == ffi.OK {
+		cBytes(value, v, vlen)
+	}
+	return C.int32_t(errno)
+}
+
+//export SkipListDelete
+func SkipListDelete(h C.uintptr_t, k unsafe.Pointer, klen C.size_t) C.int32_t {
+	key, ok := goBytes(k, klen)
+	if !ok {
+		return C.int32_t(ffi.ErrInvalidLength)
+	}
+	return C.int32_t(ffi.HandleDelete(uintptr(h), key))
+}
+
+//export SkipListRange
+func SkipListRange(h C.uintptr_t, start unsafe.Pointer, startLen C.size_t, end unsafe.Pointer, endLen C.size_t, it *C.uintptr_t) C.int32_t {
+	lo, ok := goBytes(start, startLen)
+	if !ok {
+		return C.int32_t(ffi.ErrInvalidLength)
+	}
+	hi, ok := goBytes(end, endLen)
+	if !ok {
+		return C.int32_t(ffi.ErrInvalidLength)
+	}
+	cursor, errno := ffi.HandleRange(uintptr(h), lo, hi)
+	*it = C.uintptr_t(cursor)
+	return C.int32_t(errno)
+}
+
+//export SkipListRangeNext
+func SkipListRangeNext(it C.uintptr_t, k *unsafe.Pointer, klen *C.size_t, v *unsafe.Pointer, vlen *C.size_t) C.int32_t {
+	key, value, errno := ffi.HandleRangeNext(uintptr(it))
+	if errno == ffi.OK {
+		cBytes(key, k, klen)
+		cBytes(value, v, vlen)
+	}
+	return C.int32_t(errno)
+}
+
+//export SkipListFree
+func SkipListFree(p unsafe.Pointer) {
+	C.free(p)
+}
+
+func main() {}
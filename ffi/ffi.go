@@ -0,0 +1,212 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package ffi is a flat, handle-based façade over skip lists of byte-string
+// keys and values, shaped for export across a C ABI.
+//
+// Lists and range cursors are referred to by opaque integer handles rather
+// than pointers, keys and values are copied in and out so no Go memory is
+// retained by the caller, and every function reports its outcome as an
+// Errno. A handle stays valid until it is passed to Close; closing a list
+// also closes its cursors. All functions are safe for concurrent use.
+//
+// The Go API of the parent package remains the primary interface; the
+// cshared directory builds this façade into a C shared library.
+package ffi
+
+import (
+	"sync"
+
+	skiplist "github.com/qishenonly/SkipList"
+)
+
+// Errno is the outcome of a façade call
+type Errno int32
+
+const (
+	// OK means the call succeeded
+	OK Errno = iota
+	// ErrInvalidHandle means the handle was never issued or has been closed
+	ErrInvalidHandle
+	// ErrNotFound means the key is not present
+	ErrNotFound
+	// ErrInvalidOption means the list could not be created with the given options
+	ErrInvalidOption
+	// ErrDone means a range cursor has no more entries
+	ErrDone
+	// ErrInvalidLength means a buffer length is out of range for the call
+	ErrInvalidLength
+)
+
+// store is a list behind a handle
+type store struct {
+	mu      sync.Mutex                     // Guards list
+	list    *skiplist.List[string, []byte] // Keys and values of the list
+	cursors map[uintptr]struct{}           // Handles of the open cursors over the list, guarded by the package mu
+}
+
+// cursor is a range scan behind a handle. It remembers the last key it
+// returned rather than a node, so the list may change between calls.
+type cursor struct {
+	list    uintptr // Handle of the list being scanned
+	last    string  // Last key returned
+	started bool    // Whether a key has been returned
+	start   string  // Inclusive lower bound
+	end     string  // Inclusive upper bound
+	hasEnd  bool    // Whether end applies
+}
+
+var (
+	mu         sync.Mutex                      // Guards handles and lastHandle
+	handles    = make(map[uintptr]interface{}) // Open lists and cursors by handle
+	lastHandle uintptr                         // Last handle issued; 0 is never issued
+)
+
+// register issues a new handle for v
+func register(v interface{}) uintptr {
+	mu.Lock()
+	defer mu.Unlock()
+	lastHandle++
+	handles[lastHandle] = v
+	return lastHandle
+}
+
+// lookupStore returns the list behind h, or nil if h is not an open list
+func lookupStore(h uintptr) *store {
+	mu.Lock()
+	defer mu.Unlock()
+	s, _ := handles[h].(*store)
+	return s
+}
+
+// NewHandle creates an empty list and returns its handle
+func NewHandle(opts ...skiplist.Option) (uintptr, Errno) {
+	list, err := skiplist.NewList[string, []byte](opts...)
+	if err != nil {
+		return 0, ErrInvalidOption
+	}
+	return register(&store{list: list, cursors: make(map[uintptr]struct{})}), OK
+}
+
+// Close releases a list or cursor handle. Closing a list releases the
+// handles of its cursors as well.
+func Close(h uintptr) Errno {
+	mu.Lock()
+	defer mu.Unlock()
+	switch v := handles[h].(type) {
+	case *store:
+		for c := range v.cursors {
+			delete(handles, c)
+		}
+	case *cursor:
+		if s, ok := handles[v.list].(*store); ok {
+			delete(s.cursors, h)
+		}
+	default:
+		return ErrInvalidHandle
+	}
+	delete(handles, h)
+	return OK
+}
+
+// HandlePut stores a copy of v under k, replacing any previous value
+func HandlePut(h uintptr, k, v []byte) Errno {
+	s := lookupStore(h)
+	if s == nil {
+		return ErrInvalidHandle
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Insert(string(k), append([]byte{}, v...))
+	return OK
+}
+
+// HandleGet returns a copy of the value stored under k
+func HandleGet(h uintptr, k []byte) ([]byte, Errno) {
+	s := lookupStore(h)
+	if s == nil {
+		return nil, ErrInvalidHandle
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.list.Get(string(k))
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte{}, v...), OK
+}
+
+// HandleDelete removes k from the list
+func HandleDelete(h uintptr, k []byte) Errno {
+	s := lookupStore(h)
+	if s == nil {
+		return ErrInvalidHandle
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.list.Delete(string(k)) {
+		return ErrNotFound
+	}
+	return OK
+}
+
+// HandleRange opens a cursor over the keys in [start, end] of the list and
+// returns its handle, which must be closed with Close. A nil start or end
+// leaves that side of the range open.
+func HandleRange(h uintptr, start, end []byte) (uintptr, Errno) {
+	c := &cursor{
+		list:   h,
+		start:  string(start),
+		end:    string(end),
+		hasEnd: end != nil,
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	s, _ := handles[h].(*store)
+	if s == nil {
+		return 0, ErrInvalidHandle
+	}
+	lastHandle++
+	handles[lastHandle] = c
+	s.cursors[lastHandle] = struct{}{}
+	return lastHandle, OK
+}
+
+// HandleRangeNext returns copies of the next key and value of a cursor in
+// ascending key order, or ErrDone once the range is exhausted. Each call
+// resumes after the last key returned, so keys inserted or deleted between
+// calls are seen as of the call.
+func HandleRangeNext(it uintptr) ([]byte, []byte, Errno) {
+	mu.Lock()
+	c, _ := handles[it].(*cursor)
+	mu.Unlock()
+	if c == nil {
+		return nil, nil, ErrInvalidHandle
+	}
+	s := lookupStore(c.list)
+	if s == nil {
+		return nil, nil, ErrInvalidHandle
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var k string
+	var v []byte
+	var ok bool
+	if c.started {
+		k, v, ok = s.list.Higher(c.last)
+	} else {
+		k, v, ok = s.list.Ceiling(c.start)
+	}
+	if !ok || (c.hasEnd && k > c.end) {
+		return nil, nil, ErrDone
+	}
+
+	c.last, c.started = k, true
+	return []byte(k), append([]byte{}, v...), OK
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package ffi
+
+import (
+	"testing"
+
+	skiplist "github.com/qishenonly/SkipList"
+)
+
+// newHandle creates a list handle and closes it when t ends
+func newHandle(t *testing.T) uintptr {
+	t.Helper()
+	h, errno := NewHandle()
+	if errno != OK {
+		t.Fatalf("NewHandle() = %v", errno)
+	}
+	t.Cleanup(func() { Close(h) })
+	return h
+}
+
+func TestHandlePutGetDelete(t *testing.T) {
+	h := newHandle(t)
+
+	key, value := []byte("k"), []byte("v1")
+	if errno := HandlePut(h, key, value); errno != OK {
+		t.Fatalf("HandlePut() = %v", errno)
+	}
+	// The list holds copies, so the caller's buffers may be reused.
+	value[1] = '9'
+	got, errno := HandleGet(h, key)
+	if errno != OK || string(got) != "v1" {
+		t.Fatalf("HandleGet(k) = %q, %v, want v1, OK", got, errno)
+	}
+	got[0] = 'x'
+	if again, _ := HandleGet(h, key); string(again) != "v1" {
+		t.Fatalf("Changing a returned value changed the list to %q", again)
+	}
+
+	if errno := HandlePut(h, key, []byte("v2")); errno != OK {
+		t.Fatalf("HandlePut() over an existing key = %v", errno)
+	}
+	if got, _ := HandleGet(h, key); string(got) != "v2" {
+		t.Fatalf("HandleGet(k) = %q after overwrite, want v2", got)
+	}
+
+	if errno := HandleDelete(h, key); errno != OK {
+		t.Fatalf("HandleDelete(k) = %v", errno)
+	}
+	if _, errno := HandleGet(h, key); errno != ErrNotFound {
+		t.Fatalf("HandleGet of a deleted key = %v, want ErrNotFound", errno)
+	}
+	if errno := HandleDelete(h, key); errno != ErrNotFound {
+		t.Fatalf("HandleDelete of a deleted key = %v, want ErrNotFound", errno)
+	}
+}
+
+func TestHandleRange(t *testing.T) {
+	h := newHandle(t)
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		HandlePut(h, []byte(k), []byte(k+k))
+	}
+
+	it, errno := HandleRange(h, []byte("b"), []byte("d"))
+	if errno != OK {
+		t.Fatalf("HandleRange() = %v", errno)
+	}
+	defer Close(it)
+
+	var keys []string
+	for {
+		k, v, errno := HandleRangeNext(it)
+		if errno == ErrDone {
+			break
+		}
+		if errno != OK || string(v) != string(k)+string(k) {
+			t.Fatalf("HandleRangeNext() = %q, %q, %v", k, v, errno)
+		}
+		keys = append(keys, string(k))
+		if string(k) == "b" {
+			// The cursor resumes after the last key, so writes between calls are seen.
+			HandleDelete(h, []byte("c"))
+			HandlePut(h, []byte("bb"), []byte("bbbb"))
+		}
+	}
+	if len(keys) != 3 || keys[0] != "b" || keys[1] != "bb" || keys[2] != "d" {
+		t.Fatalf("Cursor yielded %v, want [b bb d]", keys)
+	}
+}
+
+func TestStaleHandles(t *testing.T) {
+	h, _ := NewHandle()
+	HandlePut(h, []byte("k"), []byte("v"))
+	it, errno := HandleRange(h, nil, nil)
+	if errno != OK {
+		t.Fatalf("HandleRange() = %v", errno)
+	}
+
+	if errno := Close(h); errno != OK {
+		t.Fatalf("Close(list) = %v", errno)
+	}
+	if errno := Close(h); errno != ErrInvalidHandle {
+		t.Fatalf("Second Close(list) = %v, want ErrInvalidHandle", errno)
+	}
+	if errno := HandlePut(h, []byte("k"), nil); errno != ErrInvalidHandle {
+		t.Fatalf("HandlePut on a closed list = %v, want ErrInvalidHandle", errno)
+	}
+	if _, errno := HandleGet(h, []byte("k")); errno != ErrInvalidHandle {
+		t.Fatalf("HandleGet on a closed list = %v, want ErrInvalidHandle", errno)
+	}
+	if errno := HandleDelete(h, []byte("k")); errno != ErrInvalidHandle {
+		t.Fatalf("HandleDelete on a closed list = %v, want ErrInvalidHandle", errno)
+	}
+	if _, errno := HandleRange(h, nil, nil); errno != ErrInvalidHandle {
+		t.Fatalf("HandleRange on a closed list = %v, want ErrInvalidHandle", errno)
+	}
+
+	// Closing the list released its cursor too.
+	if _, _, errno := HandleRangeNext(it); errno != ErrInvalidHandle {
+		t.Fatalf("HandleRangeNext on a cursor of a closed list = %v, want ErrInvalidHandle", errno)
+	}
+	if errno := Close(it); errno != ErrInvalidHandle {
+		t.Fatalf("Close of a cursor of a closed list = %v, want ErrInvalidHandle", errno)
+	}
+	mu.Lock()
+	_, open := handles[it]
+	mu.Unlock()
+	if open {
+		t.Fatal("Cursor handle outlived its list")
+	}
+
+	if errno := Close(0); errno != ErrInvalidHandle {
+		t.Fatalf("Close(0) = %v, want ErrInvalidHandle", errno)
+	}
+	if _, errno := NewHandle(skiplist.WithMaxLevel(-1)); errno != ErrInvalidOption {
+		t.Fatalf("NewHandle with an invalid option = %v, want ErrInvalidOption", errno)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaveToFile writes the skip list to the file at path in the format of
+// GobEncode. The entries are written to a temporary file in the same
+// directory, which replaces the file at path only once it is complete and
+// synced, so a failed save leaves the previous file intact. A replaced file
+// keeps its permissions; a new one is created with mode 0600.
+func (s *SkipList) SaveToFile(path string) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("Save %s: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	if info, err := os.Stat(path); err == nil {
+		if err := f.Chmod(info.Mode().Perm()); err != nil {
+			return fmt.Errorf("Save %s: %w", path, err)
+		}
+	}
+
+	w := bufio.NewWriter(f)
+	if err := gob.NewEncoder(w).Encode(s); err != nil {
+		return fmt.Errorf("Save %s: %w", path, err)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("Save %s: %w", path, err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("Save %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("Save %s: %w", path, err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("Save %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadFromFile reads a skip list written by SaveToFile. The skip list takes
+// the key type, maximum level and probability it was saved with.
+func LoadFromFile(path string) (*SkipList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Load %s: %w", path, err)
+	}
+	defer f.Close()
+
+	s := &SkipList{}
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(s); err != nil {
+		return nil, fmt.Errorf("Load %s: %w", path, err)
+	}
+	return s, nil
+}
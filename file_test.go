@@ -0,0 +1,67 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list.gob")
+
+	s, err := New(String, WithMaxLevel(12), WithProbability(0.25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"pear", "apple", "fig", "kiwi"} {
+		s.Insert(key, len(key))
+	}
+	if err := s.SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify(t, loaded.list)
+	if !loaded.Equal(s) {
+		t.Fatalf("Loaded %v, want %v", loaded.Entries(), s.Entries())
+	}
+	if loaded.list.opts.maxLevel != 12 || loaded.list.opts.probability != 0.25 {
+		t.Fatalf("Loaded max level %d and probability %v, want 12 and 0.25", loaded.list.opts.maxLevel, loaded.list.opts.probability)
+	}
+
+	// A save that fails to encode leaves the previous file and no temporary one.
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Insert("plum", func() {})
+	if err := s.SaveToFile(path); err == nil {
+		t.Fatal("SaveToFile encoded a function value")
+	}
+	if after, err := os.ReadFile(path); err != nil || string(after) != string(before) {
+		t.Fatalf("Failed save changed the file: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("Directory holds %d files after a failed save, want 1", len(entries))
+	}
+
+	if _, err := LoadFromFile(filepath.Join(dir, "missing.gob")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("LoadFromFile of a missing file = %v, want fs.ErrNotExist", err)
+	}
+	corrupt := filepath.Join(dir, "corrupt.gob")
+	if err := os.WriteFile(corrupt, before[:len(before)/2], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFromFile(corrupt); err == nil {
+		t.Fatal("LoadFromFile read a truncated file")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// forEach calls fn for n and the nodes after it at level 0, up to max if
+// hasMax is set, until fn returns false. Steps are checked as iterators
+// check them under WithIterationChecks.
+func (l *List[K, V]) forEach(n *node[K, V], max K, hasMax bool, fn func(key K, value V) bool) {
+	for ; n != nil; n = l.next(n) {
+		if hasMax && l.compare(n.key, max) > 0 {
+			return
+		}
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// ForEach calls fn for each key-value pair in ascending key order until fn returns false
+func (l *List[K, V]) ForEach(fn func(key K, value V) bool) {
+	var zero K
+	l.forEach(l.head.forward[0], zero, false, fn)
+}
+
+// ForEachInRange calls fn for each key-value pair with min <= key <= max
+// in ascending key order until fn returns false
+func (l *List[K, V]) ForEachInRange(min, max K, fn func(key K, value V) bool) {
+	l.forEach(l.seek(min), max, true, fn)
+}
+
+// ForEach calls fn for each key-value pair in ascending key order until fn returns false
+func (s *SkipList) ForEach(fn func(key, value interface{}) bool) {
+	s.list.ForEach(fn)
+}
+
+// ForEachInRange calls fn for each key-value pair with start <= key <= end
+// in ascending key order until fn returns false. A nil start means from the
+// first key and a nil end means up to the last key. Bounds that fail
+// CheckRange visit nothing.
+func (s *SkipList) ForEachInRange(start, end interface{}, fn func(key, value interface{}) bool) {
+	if s.checkBounds(start, end) != nil {
+		return
+	}
+
+	from := s.list.head.forward[0]
+	if start != nil {
+		from = s.list.seek(start)
+	}
+	s.list.forEach(from, end, end != nil, fn)
+}
+
+// ForEach calls fn for each key-value pair in ascending key order until fn
+// returns false. The read lock is held for the whole walk, so fn must not
+// write to the same list.
+func (c *ConcurrentSkipList) ForEach(fn func(key, value interface{}) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.list.ForEach(fn)
+}
+
+// ForEachInRange calls fn for each key-value pair with start <= key <= end
+// in ascending key order until fn returns false. The read lock is held for
+// the whole walk, so fn must not write to the same list.
+func (c *ConcurrentSkipList) ForEachInRange(start, end interface{}, fn func(key, value interface{}) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.list.ForEachInRange(start, end, fn)
+}
@@ -0,0 +1,320 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// FrozenList is a read-only snapshot of a skip list kept as a sorted keys
+// slice and a parallel values slice. Lookups are binary searches, ranks
+// are indexes and ranges are subslices, and there are no towers or nodes
+// to allocate. It is safe for concurrent use by readers.
+type FrozenList[K any, V any] struct {
+	keys    []K              // Keys in ascending order
+	values  []V              // Value of each key, by index
+	compare func(a, b K) int // Comparison function of the source list
+}
+
+// Compile copies the entries of the skip list into a FrozenList ordered
+// by the same comparison function, passing the values through the cloner
+// set with WithValueCloner. Later changes to l do not affect it.
+func (l *List[K, V]) Compile() *FrozenList[K, V] {
+	f := &FrozenList[K, V]{
+		keys:    make([]K, 0, l.length),
+		values:  make([]V, 0, l.length),
+		compare: l.compare,
+	}
+	copyValue := l.valueCloner()
+	for n := l.head.forward[0]; n != nil; n = n.forward[0] {
+		f.keys = append(f.keys, n.key)
+		if copyValue != nil {
+			f.values = append(f.values, copyValue(n.value))
+		} else {
+			f.values = append(f.values, n.value)
+		}
+	}
+	return f
+}
+
+// entry returns the key and value at index i and whether i is in range
+func (f *FrozenList[K, V]) entry(i int) (K, V, bool) {
+	if i < 0 || i >= len(f.keys) {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return f.keys[i], f.values[i], true
+}
+
+// Len returns the number of keys in the frozen list
+func (f *FrozenList[K, V]) Len() int {
+	return len(f.keys)
+}
+
+// Get returns the value stored under key and whether the key was found
+func (f *FrozenList[K, V]) Get(key K) (V, bool) {
+	i, found := slices.BinarySearchFunc(f.keys, key, f.compare)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return f.values[i], true
+}
+
+// Contains reports whether key is present in the frozen list
+func (f *FrozenList[K, V]) Contains(key K) bool {
+	_, found := slices.BinarySearchFunc(f.keys, key, f.compare)
+	return found
+}
+
+// Rank returns the 0-based rank of key and whether it is present
+func (f *FrozenList[K, V]) Rank(key K) (int, bool) {
+	return slices.BinarySearchFunc(f.keys, key, f.compare)
+}
+
+// GetByRank returns the key and value with the given 0-based rank and
+// whether the rank is in range
+func (f *FrozenList[K, V]) GetByRank(rank int) (K, V, bool) {
+	return f.entry(rank)
+}
+
+// First returns the smallest key in the frozen list, with its value
+func (f *FrozenList[K, V]) First() (K, V, bool) {
+	return f.entry(0)
+}
+
+// Last returns the largest key in the frozen list, with its value
+func (f *FrozenList[K, V]) Last() (K, V, bool) {
+	return f.entry(len(f.keys) - 1)
+}
+
+// Floor returns the largest key less than or equal to key, with its value
+func (f *FrozenList[K, V]) Floor(key K) (K, V, bool) {
+	i, found := slices.BinarySearchFunc(f.keys, key, f.compare)
+	if found {
+		return f.entry(i)
+	}
+	return f.entry(i - 1)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, with its value
+func (f *FrozenList[K, V]) Ceiling(key K) (K, V, bool) {
+	i, _ := slices.BinarySearchFunc(f.keys, key, f.compare)
+	return f.entry(i)
+}
+
+// bounds returns the indexes [lo, hi) of the keys with min <= key <= max
+func (f *FrozenList[K, V]) bounds(min, max K) (int, int) {
+	lo, _ := slices.BinarySearchFunc(f.keys, min, f.compare)
+	hi, found := slices.BinarySearchFunc(f.keys[lo:], max, f.compare)
+	hi += lo
+	if found {
+		hi++
+	}
+	return lo, hi
+}
+
+// RangeSlices returns the keys with min <= key <= max and their values.
+// The slices share the storage of the frozen list and must not be modified.
+func (f *FrozenList[K, V]) RangeSlices(min, max K) ([]K, []V) {
+	lo, hi := f.bounds(min, max)
+	return f.keys[lo:hi:hi], f.values[lo:hi:hi]
+}
+
+// CountRange returns the number of keys with min <= key <= max
+func (f *FrozenList[K, V]) CountRange(min, max K) int {
+	lo, hi := f.bounds(min, max)
+	return hi - lo
+}
+
+// All returns an iterator over the key-value pairs of the frozen list in
+// ascending key order
+func (f *FrozenList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i, key := range f.keys {
+			if !yield(key, f.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the frozen list in ascending order
+func (f *FrozenList[K, V]) Keys() iter.Seq[K] {
+	return slices.Values(f.keys)
+}
+
+// Values returns an iterator over the values of the frozen list in ascending key order
+func (f *FrozenList[K, V]) Values() iter.Seq[V] {
+	return slices.Values(f.values)
+}
+
+// FrozenSkipList is a read-only snapshot of a SkipList that answers
+// lookups by binary search over flat arrays. Keys are checked against the
+// key type of the source list as in SkipList, or against the type of the
+// keys it held if it had none.
+type FrozenSkipList struct {
+	list    *FrozenList[interface{}, interface{}] // Underlying frozen list
+	keyType reflect.Type                          // Key type of the source list
+}
+
+// Compile copies the entries of the skip list into a FrozenSkipList.
+// Later changes to s do not affect it.
+func (s *SkipList) Compile() *FrozenSkipList {
+	f := &FrozenSkipList{
+		list:    s.list.Compile(),
+		keyType: s.heldKeyType(),
+	}
+	f.list.compare = compareOrdered
+	return f
+}
+
+// Compile copies the entries of the skip list into a FrozenSkipList
+func (c *ConcurrentSkipList) Compile() *FrozenSkipList {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Compile()
+}
+
+// checkKey validates key as SkipList.checkKey does
+func (f *FrozenSkipList) checkKey(key interface{}) error {
+	if key == nil {
+		return ErrNilKey
+	}
+	if f.keyType == nil {
+		if !orderedType(reflect.TypeOf(key)) {
+			return fmt.Errorf("%w: %T", ErrUnorderedKeyType, key)
+		}
+	} else if reflect.TypeOf(key) != f.keyType {
+		return fmt.Errorf("%w: expected %v, got %T", ErrKeyTypeMismatch, f.keyType, key)
+	}
+	return nil
+}
+
+// Length returns the number of keys in the frozen list
+func (f *FrozenSkipList) Length() int {
+	return f.list.Len()
+}
+
+// Get returns the value stored under key and whether the key was found.
+// A nil or mismatched key is reported as not found.
+func (f *FrozenSkipList) Get(key interface{}) (interface{}, bool) {
+	if f.checkKey(key) != nil {
+		return nil, false
+	}
+	return f.list.Get(key)
+}
+
+// Contains reports whether key is present in the frozen list
+func (f *FrozenSkipList) Contains(key interface{}) bool {
+	return f.checkKey(key) == nil && f.list.Contains(key)
+}
+
+// Rank returns the 0-based rank of key. The returned error matches
+// ErrNilKey, ErrKeyTypeMismatch or ErrKeyNotFound under errors.Is.
+func (f *FrozenSkipList) Rank(key interface{}) (int, error) {
+	if err := f.checkKey(key); err != nil {
+		return 0, err
+	}
+
+	rank, found := f.list.Rank(key)
+	if !found {
+		return 0, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	return rank, nil
+}
+
+// GetByRank returns the key and value with the given 0-based rank. A rank
+// outside [0, Length()) yields ErrRankOutOfRange.
+func (f *FrozenSkipList) GetByRank(rank int) (interface{}, interface{}, error) {
+	key, value, ok := f.list.GetByRank(rank)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %d", ErrRankOutOfRange, rank)
+	}
+	return key, value, nil
+}
+
+// First returns the smallest key in the frozen list, with its value
+func (f *FrozenSkipList) First() (interface{}, interface{}, bool) {
+	return f.list.First()
+}
+
+// Last returns the largest key in the frozen list, with its value
+func (f *FrozenSkipList) Last() (interface{}, interface{}, bool) {
+	return f.list.Last()
+}
+
+// Floor returns the largest key less than or equal to key, with its value
+func (f *FrozenSkipList) Floor(key interface{}) (interface{}, interface{}, bool) {
+	if f.checkKey(key) != nil {
+		return nil, nil, false
+	}
+	return f.list.Floor(key)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, with its value
+func (f *FrozenSkipList) Ceiling(key interface{}) (interface{}, interface{}, bool) {
+	if f.checkKey(key) != nil {
+		return nil, nil, false
+	}
+	return f.list.Ceiling(key)
+}
+
+// RangeQuery returns all entries with min <= key <= max in ascending key
+// order. A nil min means from the first key and a nil max means up to the
+// last key. Mismatched or inverted bounds yield no entries.
+func (f *FrozenSkipList) RangeQuery(min, max interface{}) []Entry {
+	lo, hi := 0, f.list.Len()
+	if min != nil {
+		if f.checkKey(min) != nil {
+			return nil
+		}
+		lo, _ = slices.BinarySearchFunc(f.list.keys, min, f.list.compare)
+	}
+	if max != nil {
+		if f.checkKey(max) != nil {
+			return nil
+		}
+		var found bool
+		hi, found = slices.BinarySearchFunc(f.list.keys, max, f.list.compare)
+		if found {
+			hi++
+		}
+	}
+
+	if hi <= lo {
+		return nil
+	}
+	entries := make([]Entry, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		entries = append(entries, Entry{Key: f.list.keys[i], Value: f.list.values[i]})
+	}
+	return entries
+}
+
+// Entries returns a copy of the key-value pairs of the frozen list in
+// ascending key order
+func (f *FrozenSkipList) Entries() []Entry {
+	return f.RangeQuery(nil, nil)
+}
+
+// All returns an iterator over the key-value pairs of the frozen list in
+// ascending key order
+func (f *FrozenSkipList) All() iter.Seq2[interface{}, interface{}] {
+	return f.list.All()
+}
+
+// Keys returns an iterator over the keys of the frozen list in ascending order
+func (f *FrozenSkipList) Keys() iter.Seq[interface{}] {
+	return f.list.Keys()
+}
+
+// Values returns an iterator over the values of the frozen list in ascending key order
+func (f *FrozenSkipList) Values() iter.Seq[interface{}] {
+	return f.list.Values()
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "testing"
+
+// BenchmarkFrozen compares point and range reads of a FrozenList with those
+// of the live list it was compiled from, over 1M keys
+func BenchmarkFrozen(b *testing.B) {
+	const n = 1000000
+	l, err := NewList[int, int]()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		l.Insert(i, i)
+	}
+	f := l.Compile()
+
+	b.Run("Get/Live", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, ok := l.Get(i * 7919 % n); !ok {
+				b.Fatal("Get missed a key")
+			}
+		}
+	})
+	b.Run("Get/Frozen", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, ok := f.Get(i * 7919 % n); !ok {
+				b.Fatal("Get missed a key")
+			}
+		}
+	})
+	b.Run("Range/Live", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			start := i * 7919 % (n - 1000)
+			sum := 0
+			l.ForEachInRange(start, start+999, func(key, value int) bool {
+				sum += value
+				return true
+			})
+			if sum == 0 && start > 0 {
+				b.Fatal("Range read nothing")
+			}
+		}
+	})
+	b.Run("Range/Frozen", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			start := i * 7919 % (n - 1000)
+			sum := 0
+			_, values := f.RangeSlices(start, start+999)
+			for _, value := range values {
+				sum += value
+			}
+			if sum == 0 && start > 0 {
+				b.Fatal("Range read nothing")
+			}
+		}
+	})
+}
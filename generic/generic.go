@@ -0,0 +1,462 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package generic exposes the type-parameterized skip list under its own
+// import path for code that does not need the interface{}-based API.
+//
+// The types in this package are defined over the typed lists of the parent
+// package, so both surfaces share a single implementation: a method here
+// calls the method of the same name there. A *SkipList converts to the
+// parent's *List and back at no cost, e.g. (*skiplist.List[K, V])(list),
+// so values can be passed between the two. Options are taken from the
+// parent package, e.g.
+//
+//	list, err := generic.New[int, string](skiplist.WithMaxLevel(16))
+package generic
+
+import (
+	"cmp"
+	"io"
+	"iter"
+
+	skiplist "github.com/qishenonly/SkipList"
+)
+
+// Option configures a skip list at construction time
+type Option = skiplist.Option
+
+// Stats describes the shape of a skip list
+type Stats = skiplist.Stats
+
+// SkipList is a skip list with typed keys and values
+type SkipList[K any, V any] skiplist.List[K, V]
+
+// Iterator is the iterator for a typed skip list
+type Iterator[K any, V any] skiplist.ListIterator[K, V]
+
+// LockFreeSkipList is a skip list that is safe for concurrent use without locks
+type LockFreeSkipList[K any, V any] skiplist.LockFreeList[K, V]
+
+// New creates a new skip list ordered by the natural ordering of K
+func New[K cmp.Ordered, V any](opts ...Option) (*SkipList[K, V], error) {
+	l, err := skiplist.NewList[K, V](opts...)
+	return (*SkipList[K, V])(l), err
+}
+
+// NewFunc creates a new skip list ordered by the given comparison function,
+// which must return a negative number when a < b, a positive number when
+// a > b and zero when a == b.
+func NewFunc[K any, V any](compare func(a, b K) int, opts ...Option) (*SkipList[K, V], error) {
+	l, err := skiplist.NewListFunc[K, V](compare, opts...)
+	return (*SkipList[K, V])(l), err
+}
+
+// NewLockFree creates a new lock-free skip list ordered by the natural ordering of K
+func NewLockFree[K cmp.Ordered, V any](opts ...Option) (*LockFreeSkipList[K, V], error) {
+	l, err := skiplist.NewLockFreeList[K, V](opts...)
+	return (*LockFreeSkipList[K, V])(l), err
+}
+
+// NewLockFreeFunc creates a new lock-free skip list ordered by the given comparison function
+func NewLockFreeFunc[K any, V any](compare func(a, b K) int, opts ...Option) (*LockFreeSkipList[K, V], error) {
+	l, err := skiplist.NewLockFreeListFunc[K, V](compare, opts...)
+	return (*LockFreeSkipList[K, V])(l), err
+}
+
+// base returns l as the list of the parent package
+func (l *SkipList[K, V]) base() *skiplist.List[K, V] {
+	return (*skiplist.List[K, V])(l)
+}
+
+// Insert inserts a new key-value pair into the skip list,
+// replacing the value if the key is already present.
+func (l *SkipList[K, V]) Insert(key K, value V) {
+	l.base().Insert(key, value)
+}
+
+// Upsert inserts a new key-value pair into the skip list, replacing the
+// value if the key is already present, and reports whether a node was created
+func (l *SkipList[K, V]) Upsert(key K, value V) bool {
+	return l.base().Upsert(key, value)
+}
+
+// GetOrInsert returns the existing value for key if present. Otherwise it
+// inserts value and returns it. The loaded result is true if the value was
+// loaded, false if stored.
+func (l *SkipList[K, V]) GetOrInsert(key K, value V) (V, bool) {
+	return l.base().GetOrInsert(key, value)
+}
+
+// InsertIfAbsent inserts a new key-value pair unless the key is already
+// present and reports whether the pair was inserted
+func (l *SkipList[K, V]) InsertIfAbsent(key K, value V) bool {
+	return l.base().InsertIfAbsent(key, value)
+}
+
+// BulkInsert inserts keys[i] with values[i] for every i in a single pass
+func (l *SkipList[K, V]) BulkInsert(keys []K, values []V) error {
+	return l.base().BulkInsert(keys, values)
+}
+
+// Get returns the value stored under key and whether the key was found
+func (l *SkipList[K, V]) Get(key K) (V, bool) {
+	return l.base().Get(key)
+}
+
+// MultiGet looks up every key in keys and returns the values and found
+// flags in the order of keys
+func (l *SkipList[K, V]) MultiGet(keys []K) ([]V, []bool) {
+	return l.base().MultiGet(keys)
+}
+
+// Contains reports whether key is present in the skip list
+func (l *SkipList[K, V]) Contains(key K) bool {
+	return l.base().Contains(key)
+}
+
+// Delete deletes a key from the skip list and reports whether it was present
+func (l *SkipList[K, V]) Delete(key K) bool {
+	return l.base().Delete(key)
+}
+
+// Remove deletes a key from the skip list and returns the value it held
+// and whether it was present
+func (l *SkipList[K, V]) Remove(key K) (V, bool) {
+	return l.base().Remove(key)
+}
+
+// Replace replaces the value stored under key and reports whether the key
+// was present; a missing key is not inserted
+func (l *SkipList[K, V]) Replace(key K, value V) bool {
+	return l.base().Replace(key, value)
+}
+
+// Update replaces the value stored under key with fn applied to it and
+// reports whether the key was present
+func (l *SkipList[K, V]) Update(key K, fn func(old V) V) bool {
+	return l.base().Update(key, fn)
+}
+
+// CompareAndSwapFunc replaces the value of key with new if equal reports
+// that the stored value matches old, and reports whether it did
+func (l *SkipList[K, V]) CompareAndSwapFunc(key K, old, new V, equal func(a, b V) bool) bool {
+	return l.base().CompareAndSwapFunc(key, old, new, equal)
+}
+
+// CompareAndDeleteFunc removes key if equal reports that its value matches
+// old, and reports whether it did
+func (l *SkipList[K, V]) CompareAndDeleteFunc(key K, old V, equal func(a, b V) bool) bool {
+	return l.base().CompareAndDeleteFunc(key, old, equal)
+}
+
+// SearchForInsert looks up key like Get and also returns its position, so
+// that a following InsertAt of the same key needs no second descent
+func (l *SkipList[K, V]) SearchForInsert(key K) (V, bool, skiplist.Position[K, V]) {
+	return l.base().SearchForInsert(key)
+}
+
+// InsertAt inserts key and value at pos, or replaces the value if key was
+// present, without descending again. It reports false and changes nothing
+// if pos is stale or was taken for another key or list.
+func (l *SkipList[K, V]) InsertAt(pos skiplist.Position[K, V], key K, value V) bool {
+	return l.base().InsertAt(pos, key, value)
+}
+
+// Len returns the length of the skip list
+func (l *SkipList[K, V]) Len() int {
+	return l.base().Len()
+}
+
+// Clear removes all elements from the skip list
+func (l *SkipList[K, V]) Clear() {
+	l.base().Clear()
+}
+
+// ClearIncremental empties the skip list at once and releases the old
+// nodes over several calls, up to maxPerCall each, returning the number
+// still waiting
+func (l *SkipList[K, V]) ClearIncremental(maxPerCall int) int {
+	return l.base().ClearIncremental(maxPerCall)
+}
+
+// First returns the smallest key in the skip list, with its value, in O(1)
+func (l *SkipList[K, V]) First() (K, V, bool) {
+	return l.base().First()
+}
+
+// Last returns the largest key in the skip list, with its value, in O(1)
+func (l *SkipList[K, V]) Last() (K, V, bool) {
+	return l.base().Last()
+}
+
+// Floor returns the largest key less than or equal to key, with its value
+func (l *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	return l.base().Floor(key)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, with its value
+func (l *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return l.base().Ceiling(key)
+}
+
+// Lower returns the largest key strictly less than key, with its value
+func (l *SkipList[K, V]) Lower(key K) (K, V, bool) {
+	return l.base().Lower(key)
+}
+
+// Higher returns the smallest key strictly greater than key, with its value
+func (l *SkipList[K, V]) Higher(key K) (K, V, bool) {
+	return l.base().Higher(key)
+}
+
+// PopMin removes the smallest key from the skip list and returns it with its value
+func (l *SkipList[K, V]) PopMin() (K, V, bool) {
+	return l.base().PopMin()
+}
+
+// PopMax removes the largest key from the skip list and returns it with its value
+func (l *SkipList[K, V]) PopMax() (K, V, bool) {
+	return l.base().PopMax()
+}
+
+// Locate returns the 0-based rank key has or would have in the skip list,
+// whether it is present, and its value when it is
+func (l *SkipList[K, V]) Locate(key K) (int, bool, V) {
+	return l.base().Locate(key)
+}
+
+// Rank returns the 0-based rank of key and whether it is present
+func (l *SkipList[K, V]) Rank(key K) (int, bool) {
+	return l.base().Rank(key)
+}
+
+// GetByRank returns the key and value with the given 0-based rank and
+// whether the rank is in range
+func (l *SkipList[K, V]) GetByRank(rank int) (K, V, bool) {
+	return l.base().GetByRank(rank)
+}
+
+// Select returns the key and value with the given 0-based rank and whether
+// the rank is in range. It is the inverse of Rank.
+func (l *SkipList[K, V]) Select(rank int) (K, V, bool) {
+	return l.base().Select(rank)
+}
+
+// CountRange returns the number of keys with min <= key <= max in O(log n)
+func (l *SkipList[K, V]) CountRange(min, max K) int {
+	return l.base().CountRange(min, max)
+}
+
+// DeleteRange removes every key in [min, max] and returns how many were removed
+func (l *SkipList[K, V]) DeleteRange(min, max K) int {
+	return l.base().DeleteRange(min, max)
+}
+
+// ForEach calls fn for each key-value pair in ascending key order until fn returns false
+func (l *SkipList[K, V]) ForEach(fn func(key K, value V) bool) {
+	l.base().ForEach(fn)
+}
+
+// ForEachInRange calls fn for each key-value pair with min <= key <= max
+// in ascending key order until fn returns false
+func (l *SkipList[K, V]) ForEachInRange(min, max K, fn func(key K, value V) bool) {
+	l.base().ForEachInRange(min, max, fn)
+}
+
+// All returns an iterator over the key-value pairs of the skip list in
+// ascending key order, for use with range
+func (l *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return l.base().All()
+}
+
+// Keys returns an iterator over the keys of the skip list in ascending order
+func (l *SkipList[K, V]) Keys() iter.Seq[K] {
+	return l.base().Keys()
+}
+
+// Values returns an iterator over the values of the skip list in ascending key order
+func (l *SkipList[K, V]) Values() iter.Seq[V] {
+	return l.base().Values()
+}
+
+// Oldest returns an iterator over the key-value pairs of the skip list
+// from the earliest inserted to the latest. It yields nothing unless the
+// list was created with WithInsertionOrder.
+func (l *SkipList[K, V]) Oldest() iter.Seq2[K, V] {
+	return l.base().Oldest()
+}
+
+// Newest returns an iterator over the key-value pairs of the skip list
+// from the latest inserted to the earliest. It yields nothing unless the
+// list was created with WithInsertionOrder.
+func (l *SkipList[K, V]) Newest() iter.Seq2[K, V] {
+	return l.base().Newest()
+}
+
+// Iterator returns a new iterator for the skip list
+func (l *SkipList[K, V]) Iterator() *Iterator[K, V] {
+	return (*Iterator[K, V])(l.base().Iterator())
+}
+
+// ReverseIterator returns a new iterator for the skip list whose Next
+// walks from the largest key towards the smallest one
+func (l *SkipList[K, V]) ReverseIterator() *Iterator[K, V] {
+	return (*Iterator[K, V])(l.base().ReverseIterator())
+}
+
+// Range returns an iterator over the keys in [min, max]
+func (l *SkipList[K, V]) Range(min, max K) *Iterator[K, V] {
+	return (*Iterator[K, V])(l.base().Range(min, max))
+}
+
+// SnapshotIterator returns an iterator over a copy of the skip list taken
+// when it is called
+func (l *SkipList[K, V]) SnapshotIterator() *Iterator[K, V] {
+	return (*Iterator[K, V])(l.base().SnapshotIterator())
+}
+
+// Clone returns an independent copy of the skip list with the same
+// comparison function and options
+func (l *SkipList[K, V]) Clone() *SkipList[K, V] {
+	return (*SkipList[K, V])(l.base().Clone())
+}
+
+// CloneWith returns an independent copy of the skip list like Clone, with
+// every value replaced by copyValue(value)
+func (l *SkipList[K, V]) CloneWith(copyValue func(V) V) *SkipList[K, V] {
+	return (*SkipList[K, V])(l.base().CloneWith(copyValue))
+}
+
+// Split removes every key >= key from the skip list and returns them in a
+// new list with the same comparison function and options
+func (l *SkipList[K, V]) Split(key K) *SkipList[K, V] {
+	return (*SkipList[K, V])(l.base().Split(key))
+}
+
+// Merge moves every key of other into l and leaves other empty. For a key
+// present in both, onConflict returns the value to keep.
+func (l *SkipList[K, V]) Merge(other *SkipList[K, V], onConflict func(key K, a, b V) V) {
+	l.base().Merge(other.base(), onConflict)
+}
+
+// EqualFunc reports whether l and other hold the same keys with values
+// that equal reports as equal
+func (l *SkipList[K, V]) EqualFunc(other *SkipList[K, V], equal func(a, b V) bool) bool {
+	return l.base().EqualFunc(other.base(), equal)
+}
+
+// Compile copies the entries of the skip list into a FrozenList ordered
+// by the same comparison function
+func (l *SkipList[K, V]) Compile() *skiplist.FrozenList[K, V] {
+	return l.base().Compile()
+}
+
+// Reconfigure changes options of the live skip list
+func (l *SkipList[K, V]) Reconfigure(opts ...Option) error {
+	return l.base().Reconfigure(opts...)
+}
+
+// CheckSorted verifies that the keys at every level are strictly increasing
+func (l *SkipList[K, V]) CheckSorted() error {
+	return l.base().CheckSorted()
+}
+
+// Stats walks every level of the skip list and tallies its nodes
+func (l *SkipList[K, V]) Stats() Stats {
+	return l.base().Stats()
+}
+
+// Dump writes the structure of the skip list to w, showing at most limit nodes
+func (l *SkipList[K, V]) Dump(w io.Writer, limit int) error {
+	return l.base().Dump(w, limit)
+}
+
+// String renders the structure of the skip list for debugging
+func (l *SkipList[K, V]) String() string {
+	return l.base().String()
+}
+
+// base returns it as the iterator of the parent package
+func (it *Iterator[K, V]) base() *skiplist.ListIterator[K, V] {
+	return (*skiplist.ListIterator[K, V])(it)
+}
+
+// Next moves the iterator to the next node and returns true if successful
+func (it *Iterator[K, V]) Next() bool {
+	return it.base().Next()
+}
+
+// Prev moves the iterator to the previous node and returns true if successful
+func (it *Iterator[K, V]) Prev() bool {
+	return it.base().Prev()
+}
+
+// Seek positions the iterator at the first node whose key is >= key and
+// returns true if there is one
+func (it *Iterator[K, V]) Seek(key K) bool {
+	return it.base().Seek(key)
+}
+
+// SeekToLast positions the iterator at the largest key within its bounds
+// and returns true if there is one
+func (it *Iterator[K, V]) SeekToLast() bool {
+	return it.base().SeekToLast()
+}
+
+// Valid reports whether the iterator is positioned at a node
+func (it *Iterator[K, V]) Valid() bool {
+	return it.base().Valid()
+}
+
+// Key returns the key of the current node being iterated
+func (it *Iterator[K, V]) Key() K {
+	return it.base().Key()
+}
+
+// Value returns the value of the current node being iterated
+func (it *Iterator[K, V]) Value() V {
+	return it.base().Value()
+}
+
+// base returns l as the lock-free list of the parent package
+func (l *LockFreeSkipList[K, V]) base() *skiplist.LockFreeList[K, V] {
+	return (*skiplist.LockFreeList[K, V])(l)
+}
+
+// Insert inserts a new key-value pair into the skip list, replacing the
+// value if the key is already present, and reports whether a node was created
+func (l *LockFreeSkipList[K, V]) Insert(key K, value V) bool {
+	return l.base().Insert(key, value)
+}
+
+// Get returns the value stored under key and whether the key was found
+func (l *LockFreeSkipList[K, V]) Get(key K) (V, bool) {
+	return l.base().Get(key)
+}
+
+// Contains reports whether key is present in the skip list
+func (l *LockFreeSkipList[K, V]) Contains(key K) bool {
+	return l.base().Contains(key)
+}
+
+// Delete deletes a key from the skip list and reports whether it was present
+func (l *LockFreeSkipList[K, V]) Delete(key K) bool {
+	return l.base().Delete(key)
+}
+
+// Remove deletes a key from the skip list and returns the value it held
+// and whether this call removed it
+func (l *LockFreeSkipList[K, V]) Remove(key K) (V, bool) {
+	return l.base().Remove(key)
+}
+
+// Len returns the length of the skip list
+func (l *LockFreeSkipList[K, V]) Len() int {
+	return l.base().Len()
+}
+
+// ForEach calls fn for each key-value pair in ascending key order until fn
+// returns false
+func (l *LockFreeSkipList[K, V]) ForEach(fn func(key K, value V) bool) {
+	l.base().ForEach(fn)
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package generic_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	skiplist "github.com/qishenonly/SkipList"
+	"github.com/qishenonly/SkipList/conformance"
+	"github.com/qishenonly/SkipList/generic"
+)
+
+// typedMap adapts a typed skip list with int keys to conformance.Map
+type typedMap struct {
+	list *generic.SkipList[int, interface{}]
+}
+
+func (m typedMap) Insert(key, value interface{}) error {
+	if key == nil {
+		return skiplist.ErrNilKey
+	}
+	m.list.Upsert(key.(int), value)
+	return nil
+}
+
+func (m typedMap) Get(key interface{}) (interface{}, bool) {
+	if key == nil {
+		return nil, false
+	}
+	return m.list.Get(key.(int))
+}
+
+func (m typedMap) Delete(key interface{}) error {
+	if key == nil {
+		return skiplist.ErrNilKey
+	}
+	if !m.list.Delete(key.(int)) {
+		return skiplist.ErrKeyNotFound
+	}
+	return nil
+}
+
+func (m typedMap) Length() int {
+	return m.list.Len()
+}
+
+func (m typedMap) Min() (interface{}, interface{}, bool) {
+	key, value, ok := m.list.First()
+	return key, value, ok
+}
+
+func (m typedMap) Max() (interface{}, interface{}, bool) {
+	key, value, ok := m.list.Last()
+	return key, value, ok
+}
+
+// Ascend calls fn for every key in [min, max] in ascending order
+func (m typedMap) Ascend(min, max interface{}, fn func(key, value interface{}) bool) {
+	lo, hi := math.MinInt, math.MaxInt
+	if min != nil {
+		lo = min.(int)
+	}
+	if max != nil {
+		hi = max.(int)
+	}
+	m.list.ForEachInRange(lo, hi, func(key int, value interface{}) bool {
+		return fn(key, value)
+	})
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func() conformance.Map {
+		l, err := generic.New[int, interface{}]()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return typedMap{l}
+	})
+}
+
+func TestParentConversion(t *testing.T) {
+	l, err := generic.New[string, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Insert("b", 2)
+
+	// Both surfaces see the same list.
+	parent := (*skiplist.List[string, int])(l)
+	parent.Insert("a", 1)
+	if v, ok := l.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %d, %v after inserting through the parent, want 1, true", v, ok)
+	}
+
+	var keys []string
+	for it := l.Iterator(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Fatalf("Iterator yielded %v, want [a b]", keys)
+	}
+	if err := l.CheckSorted(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// noReadAllocs fails t unless Get, Contains, Floor and Ceiling of l make
+// no allocation for a present and a missing key
+func noReadAllocs[K any](t *testing.T, l *generic.SkipList[K, int], hit, miss K) {
+	t.Helper()
+	reads := map[string]func(key K){
+		"Get":      func(key K) { l.Get(key) },
+		"Contains": func(key K) { l.Contains(key) },
+		"Floor":    func(key K) { l.Floor(key) },
+		"Ceiling":  func(key K) { l.Ceiling(key) },
+	}
+	for name, read := range reads {
+		for _, key := range []K{hit, miss} {
+			if allocs := testing.AllocsPerRun(100, func() { read(key) }); allocs != 0 {
+				t.Errorf("%s(%v) made %v allocations, want 0", name, key, allocs)
+			}
+		}
+	}
+}
+
+func TestReadAllocs(t *testing.T) {
+	ints, err := generic.New[int, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	strs, err := generic.New[string, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i += 2 {
+		ints.Insert(i, i)
+		strs.Insert(strconv.Itoa(i), i)
+	}
+
+	noReadAllocs(t, ints, 500, 501)
+	noReadAllocs(t, strs, "500", "501")
+}
@@ -1 +1,3 @@
 module github.com/qishenonly/SkipList
+
+go 1.23
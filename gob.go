@@ -0,0 +1,112 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+)
+
+// orderedKeyTypes lists the key types a SkipList orders, by name
+var orderedKeyTypes = func() map[string]reflect.Type {
+	types := make(map[string]reflect.Type)
+	for _, v := range []interface{}{
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0), uintptr(0),
+		"", float64(0), float32(0),
+	} {
+		t := reflect.TypeOf(v)
+		types[t.String()] = t
+	}
+	return types
+}()
+
+// gobList is the gob form of a SkipList
+type gobList struct {
+	KeyType     string        // Name of the key type, empty for none
+	MaxLevel    int           // Maximum level of the list
+	Probability float64       // Probability of promoting a node
+	Keys        []interface{} // Keys in ascending order
+	Values      []interface{} // Values in the order of Keys
+}
+
+// GobEncode encodes the key type, the maximum level and probability, and
+// the entries of the skip list in ascending key order. Keys and values are
+// encoded as interface values, so types other than the basic ones must be
+// registered with gob.Register, and values must be gob-encodable: channels,
+// functions and structs without exported fields are not.
+func (s *SkipList) GobEncode() ([]byte, error) {
+	g := gobList{
+		MaxLevel:    s.list.opts.maxLevel,
+		Probability: s.list.opts.probability,
+		Keys:        make([]interface{}, 0, s.list.length),
+		Values:      make([]interface{}, 0, s.list.length),
+	}
+	if s.keyType != nil {
+		if orderedKeyTypes[s.keyType.String()] != s.keyType {
+			return nil, fmt.Errorf("Key type %v cannot be encoded", s.keyType)
+		}
+		g.KeyType = s.keyType.String()
+	}
+
+	for n := s.list.head.forward[0]; n != nil; n = n.forward[0] {
+		g.Keys = append(g.Keys, n.key)
+		g.Values = append(g.Values, n.value)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the contents of the skip list with entries encoded by
+// GobEncode, inserting them in a single pass. A zero SkipList takes the key
+// type, maximum level and probability that were encoded; an initialized one
+// keeps its configuration and must have the encoded key type. The skip list
+// is left unchanged if the data is rejected.
+func (s *SkipList) GobDecode(data []byte) error {
+	var g gobList
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+
+	var keyType reflect.Type
+	if g.KeyType != "" {
+		keyType = orderedKeyTypes[g.KeyType]
+		if keyType == nil {
+			return fmt.Errorf("Unknown key type %q", g.KeyType)
+		}
+	}
+
+	if s.list != nil && keyType != s.keyType {
+		return fmt.Errorf("%w: expected %v, got %v", ErrKeyTypeMismatch, s.keyType, keyType)
+	}
+	if len(g.Keys) != len(g.Values) {
+		return fmt.Errorf("Got %d keys but %d values", len(g.Keys), len(g.Values))
+	}
+	check := &SkipList{keyType: keyType}
+	if err := check.checkKeys(g.Keys); err != nil {
+		return err
+	}
+	for i, key := range g.Keys {
+		if i > 0 && compareOrdered(g.Keys[i-1], key) >= 0 {
+			return fmt.Errorf("%w: key %v at index %d does not follow %v", ErrNotSorted, key, i, g.Keys[i-1])
+		}
+	}
+
+	if s.list == nil {
+		s.keyType = keyType
+		if err := s.init([]Option{WithMaxLevel(g.MaxLevel), WithProbability(g.Probability)}); err != nil {
+			s.keyType = nil
+			return err
+		}
+	} else {
+		s.list.Clear()
+	}
+	return s.list.BulkInsert(g.Keys, g.Values)
+}
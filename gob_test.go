@@ -0,0 +1,83 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// gobSnapshots returns the GobEncode output of lists of a few sizes and key types
+func gobSnapshots(t testing.TB) [][]byte {
+	var snapshots [][]byte
+	for _, keyType := range []reflect.Type{Int, String, nil} {
+		for _, n := range []int{0, 1, 20} {
+			s := NewSkipList(keyType)
+			for i := 0; i < n; i++ {
+				if keyType == String {
+					s.Insert(strconv.Itoa(i), i)
+				} else {
+					s.Insert(i*3, strconv.Itoa(i))
+				}
+			}
+			data, err := s.GobEncode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			snapshots = append(snapshots, data)
+		}
+	}
+	return snapshots
+}
+
+// checkGobDecode decodes data into a zero SkipList and fails t unless it
+// is rejected or yields a well-formed list that encodes back to the same entries
+func checkGobDecode(t *testing.T, data []byte) {
+	t.Helper()
+	var s SkipList
+	if err := s.GobDecode(data); err != nil {
+		if s.list != nil {
+			t.Fatalf("Rejected data %q initialized the list", data)
+		}
+		return
+	}
+	verify(t, s.list)
+
+	again, err := s.GobEncode()
+	if err != nil {
+		t.Fatalf("GobEncode of a decoded list: %v", err)
+	}
+	var round SkipList
+	if err := round.GobDecode(again); err != nil {
+		t.Fatalf("GobDecode of a re-encoded list: %v", err)
+	}
+	if !reflect.DeepEqual(round.Entries(), s.Entries()) {
+		t.Fatal("Re-encoded list decodes to different entries")
+	}
+}
+
+func TestGobDecodeMutated(t *testing.T) {
+	// Every byte of every snapshot is replaced with values that shift
+	// lengths, counts and type ids, and truncated after it.
+	for _, data := range gobSnapshots(t) {
+		for i := range data {
+			for _, b := range []byte{0, 0x80, 0xff, data[i] ^ 1} {
+				mutated := append([]byte(nil), data...)
+				mutated[i] = b
+				checkGobDecode(t, mutated)
+			}
+			checkGobDecode(t, data[:i])
+		}
+	}
+}
+
+// FuzzGobDecode feeds arbitrary data to GobDecode, which LoadFromFile uses,
+// seeded with valid snapshots
+func FuzzGobDecode(f *testing.F) {
+	for _, data := range gobSnapshots(f) {
+		f.Add(data)
+	}
+	f.Fuzz(checkGobDecode)
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonEntry is the JSON form of an entry
+type jsonEntry struct {
+	Key   json.RawMessage `json:"key"`   // Encoded key
+	Value json.RawMessage `json:"value"` // Encoded value
+}
+
+// WithValueDecoder sets how UnmarshalJSON turns the JSON text of each value
+// into the value to store. Without it values are decoded as by
+// json.Unmarshal into an interface{}, so numbers become float64 and objects
+// become map[string]interface{}.
+func WithValueDecoder(decode func(data []byte) (interface{}, error)) Option {
+	return func(o *options) error {
+		if decode == nil {
+			return fmt.Errorf("Value decoder cannot be nil")
+		}
+		o.valueDecoder = decode
+		return nil
+	}
+}
+
+// MarshalJSON encodes the skip list as an array of {"key": ..., "value": ...}
+// objects in ascending key order
+func (s *SkipList) MarshalJSON() ([]byte, error) {
+	entries := make([]jsonEntry, 0, s.list.length)
+	for n := s.list.head.forward[0]; n != nil; n = n.forward[0] {
+		key, err := json.Marshal(n.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(n.value)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, jsonEntry{Key: key, Value: value})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the contents of the skip list with the entries
+// encoded by MarshalJSON. Keys are decoded into the key type of the list,
+// so an Int list gets its int keys back; a list without a key type gets
+// float64 keys for JSON numbers. Values are decoded by the decoder set with
+// WithValueDecoder. A later entry for the same key replaces an earlier one.
+// A zero SkipList is initialized with the default options and no key type.
+// The skip list is left unchanged if any entry fails to decode.
+func (s *SkipList) UnmarshalJSON(data []byte) error {
+	var entries []jsonEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	if s.list == nil {
+		if err := s.init(nil); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]interface{}, len(entries))
+	values := make([]interface{}, len(entries))
+	for i, e := range entries {
+		key, err := s.decodeKey(e.Key)
+		if err != nil {
+			return fmt.Errorf("Entry %d: %w", i, err)
+		}
+		value, err := s.decodeValue(e.Value)
+		if err != nil {
+			return fmt.Errorf("Entry %d: %w", i, err)
+		}
+		keys[i], values[i] = key, value
+	}
+
+	s.list.Clear()
+	for i, key := range keys {
+		s.list.Insert(key, values[i])
+	}
+	return nil
+}
+
+// decodeKey decodes a key into the key type of the skip list
+func (s *SkipList) decodeKey(data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, ErrNilKey
+	}
+
+	var key interface{}
+	if s.keyType != nil {
+		ptr := reflect.New(s.keyType)
+		if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+			return nil, err
+		}
+		key = ptr.Elem().Interface()
+	} else if err := json.Unmarshal(data, &key); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// decodeValue decodes a value with the configured decoder. A missing value
+// is decoded as null.
+func (s *SkipList) decodeValue(data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+
+	if decode := s.list.opts.valueDecoder; decode != nil {
+		return decode(data)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
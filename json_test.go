@@ -0,0 +1,48 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// FuzzUnmarshalJSON feeds arbitrary text to UnmarshalJSON of an Int list
+// and of a zero one. A rejected text must leave the Int list as it was; an
+// accepted one must build a well-formed list.
+func FuzzUnmarshalJSON(f *testing.F) {
+	s := NewSkipList(Int)
+	for i := 0; i < 10; i++ {
+		s.Insert(i*i, []interface{}{i, "v"})
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`[{"key": 1.5, "value": null}, {"key": "a"}]`))
+	f.Add([]byte(`[{"key": 2, "value": 1}, {"key": 1, "value": 2}, {"key": 2, "value": 3}]`))
+	f.Add([]byte(`[{"value": 1}]`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := NewSkipList(Int)
+		s.Insert(-1, "kept")
+		if err := s.UnmarshalJSON(data); err != nil {
+			if value, ok := s.Get(-1); s.Length() != 1 || !ok || value != "kept" {
+				t.Fatalf("Rejected text %q changed the list", data)
+			}
+		} else {
+			verify(t, s.list)
+		}
+
+		var zero SkipList
+		if zero.UnmarshalJSON(data) == nil {
+			verify(t, zero.list)
+			if _, err := json.Marshal(&zero); err != nil {
+				t.Fatalf("MarshalJSON of a decoded list: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,563 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"cmp"
+	"math/bits"
+	"math/rand"
+	"time"
+)
+
+// node represents a node in the skip list
+type node[K any, V any] struct {
+	key      K             // Key of the node
+	value    V             // Value of the node
+	forward  []*node[K, V] // Forward pointers of the node
+	span     []int         // Number of level-0 links crossed by each forward pointer
+	backward *node[K, V]   // Previous node at level 0, nil for the first node
+	older    *node[K, V]   // Node inserted before, kept only with WithInsertionOrder
+	newer    *node[K, V]   // Node inserted after, kept only with WithInsertionOrder
+}
+
+// List is a skip list with typed keys and values.
+//
+// List holds the skip list algorithm shared by every surface of this
+// package: the interface{}-based SkipList is a thin wrapper around a
+// List[interface{}, interface{}], so keys and values stored in a List
+// with concrete type parameters are never boxed.
+type List[K any, V any] struct {
+	head    *node[K, V]      // Head node of the skip list
+	tail    *node[K, V]      // Last node of the skip list, nil if it is empty
+	level   int              // Current level of the skip list
+	length  int              // Length of the skip list (number of nodes)
+	compare func(a, b K) int // Comparison function for the keys
+	opts    options          // Configuration of the skip list
+	rng     *rand.Rand       // Source of randomness for node levels
+	mods    uint64           // Number of structural changes, to detect stale positions
+
+	pending     *node[K, V] // First detached node not yet released by ClearIncremental
+	pendingTail *node[K, V] // Last detached node not yet released by ClearIncremental
+	pendingLen  int         // Number of detached nodes not yet released
+
+	order *insertionOrder[K, V] // Insertion-order chain, nil unless WithInsertionOrder is set
+}
+
+// ListIterator represents the iterator for a typed skip list
+type ListIterator[K any, V any] struct {
+	list     *List[K, V] // The skip list associated with the iterator
+	node     *node[K, V] // Current node being iterated, nil once exhausted by Seek
+	isHead   bool        // Flag to indicate the iterator is positioned before node.forward[0]
+	reverse  bool        // Whether Next walks towards smaller keys
+	lower    K           // Inclusive lower bound of a range iterator
+	upper    K           // Inclusive upper bound of a range iterator
+	hasLower bool        // Whether lower applies
+	hasUpper bool        // Whether upper applies
+	empty    bool        // Whether the iterator was made over invalid bounds and never moves
+}
+
+// NewList creates a new typed skip list ordered by the natural ordering of K
+func NewList[K cmp.Ordered, V any](opts ...Option) (*List[K, V], error) {
+	return NewListFunc[K, V](cmp.Compare[K], opts...)
+}
+
+// NewListFunc creates a new typed skip list ordered by the given comparison
+// function, which must return a negative number when a < b, a positive
+// number when a > b and zero when a == b.
+//
+// Mutating methods call compare only while locating the position of the
+// key, before any pointer is modified, so a panicking comparison function
+// unwinds through the caller and leaves the list exactly as it was.
+func NewListFunc[K any, V any](compare func(a, b K) int, opts ...Option) (*List[K, V], error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	head := &node[K, V]{
+		forward: make([]*node[K, V], o.maxLevel),
+		span:    make([]int, o.maxLevel),
+	}
+	l := &List[K, V]{
+		head:    head,
+		level:   1,
+		length:  0,
+		compare: compare,
+		opts:    o,
+		rng:     newRand(o.randSource),
+	}
+	if o.insertionOrder {
+		l.order = newInsertionOrder[K, V](o.moveOnOverwrite)
+	}
+	return l, nil
+}
+
+// newRand returns a generator reading from src, or from a new time-seeded
+// source if src is nil
+func newRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+	return rand.New(src)
+}
+
+// randomLevel generates a random level for the new node in the skip list.
+// With the default probability of 1/2 each level is one more trailing zero
+// bit of a single random word; other probabilities draw a float per level.
+func (l *List[K, V]) randomLevel() int {
+	limit := l.opts.towerLimit()
+	level := 1
+	if l.opts.probability == 0.5 {
+		level += bits.TrailingZeros64(l.rng.Uint64())
+		return min(level, limit)
+	}
+
+	for level < limit && l.rng.Float64() < l.opts.probability {
+		level++
+	}
+	return level
+}
+
+// Insert inserts a new key-value pair into the skip list,
+// replacing the value if the key is already present.
+func (l *List[K, V]) Insert(key K, value V) {
+	l.Upsert(key, value)
+}
+
+// Upsert inserts a new key-value pair into the skip list, replacing the
+// value if the key is already present, and reports whether a node was created
+func (l *List[K, V]) Upsert(key K, value V) bool {
+	// update and rank are sized to the head tower so that promoting the
+	// list to a new level never indexes past their end.
+	update := make([]*node[K, V], len(l.head.forward))
+	rank := make([]int, len(l.head.forward))
+
+	if current := l.path(key, update, rank); current != nil && l.compare(current.key, key) == 0 {
+		current.value = value
+		l.order.touch(current)
+		return false
+	}
+
+	l.link(update, rank, key, value)
+	return true
+}
+
+// GetOrInsert returns the existing value for key if present. Otherwise it
+// inserts value and returns it. The loaded result is true if the value was
+// loaded, false if stored.
+func (l *List[K, V]) GetOrInsert(key K, value V) (V, bool) {
+	update := make([]*node[K, V], len(l.head.forward))
+	rank := make([]int, len(l.head.forward))
+
+	if current := l.path(key, update, rank); current != nil && l.compare(current.key, key) == 0 {
+		return current.value, true
+	}
+
+	l.link(update, rank, key, value)
+	return value, false
+}
+
+// InsertIfAbsent inserts a new key-value pair unless the key is already
+// present, in which case the stored value is left untouched. It reports
+// whether the pair was inserted.
+func (l *List[K, V]) InsertIfAbsent(key K, value V) bool {
+	_, loaded := l.GetOrInsert(key, value)
+	return !loaded
+}
+
+// path fills update with the rightmost node whose key is < key at every
+// level, and rank with the number of nodes up to and including each of
+// them, then returns the level-0 successor of update[0]
+func (l *List[K, V]) path(key K, update []*node[K, V], rank []int) *node[K, V] {
+	current := l.head
+	traversed := 0
+
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward[i] != nil && l.compare(current.forward[i].key, key) < 0 {
+			traversed += current.span[i]
+			current = current.forward[i]
+		}
+		update[i] = current
+		rank[i] = traversed
+	}
+
+	return current.forward[0]
+}
+
+// link splices a new node holding key and value after the nodes recorded
+// by path in update and rank, and returns it.
+//
+// compare is never called here: a panic in it has to leave the list
+// untouched, so all comparisons happen in path.
+func (l *List[K, V]) link(update []*node[K, V], rank []int, key K, value V) *node[K, V] {
+	level := l.randomLevel()
+
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = l.head
+			rank[i] = 0
+			l.head.span[i] = l.length
+		}
+		l.level = level
+	}
+
+	newNode := &node[K, V]{
+		key:     key,
+		value:   value,
+		forward: make([]*node[K, V], level),
+		span:    make([]int, level),
+	}
+
+	for i := 0; i < level; i++ {
+		newNode.forward[i] = update[i].forward[i]
+		update[i].forward[i] = newNode
+
+		newNode.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < l.level; i++ {
+		update[i].span[i]++
+	}
+
+	if update[0] != l.head {
+		newNode.backward = update[0]
+	}
+	if newNode.forward[0] != nil {
+		newNode.forward[0].backward = newNode
+	} else {
+		l.tail = newNode
+	}
+
+	l.order.push(newNode)
+	l.length++
+	l.mods++
+
+	return newNode
+}
+
+// unlink removes n from every level, given the nodes recorded by path for
+// its key in update
+func (l *List[K, V]) unlink(update []*node[K, V], n *node[K, V]) {
+	for i := 0; i < l.level; i++ {
+		if update[i].forward[i] == n {
+			update[i].span[i] += n.span[i] - 1
+			update[i].forward[i] = n.forward[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+
+	if n.forward[0] != nil {
+		n.forward[0].backward = n.backward
+	} else {
+		l.tail = n.backward
+	}
+
+	for l.level > 1 && l.head.forward[l.level-1] == nil {
+		l.level--
+	}
+
+	l.order.remove(n)
+	l.length--
+	l.mods++
+}
+
+// Get returns the value stored under key and whether the key was found
+func (l *List[K, V]) Get(key K) (V, bool) {
+	if n := l.find(key); n != nil {
+		return n.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is present in the skip list
+func (l *List[K, V]) Contains(key K) bool {
+	return l.find(key) != nil
+}
+
+// Delete deletes a key from the skip list and reports whether it was present
+func (l *List[K, V]) Delete(key K) bool {
+	_, ok := l.Remove(key)
+	return ok
+}
+
+// Remove deletes a key from the skip list and returns the value it held
+// and whether it was present
+func (l *List[K, V]) Remove(key K) (V, bool) {
+	update := make([]*node[K, V], l.level)
+	rank := make([]int, l.level)
+
+	current := l.path(key, update, rank)
+	if current == nil || l.compare(current.key, key) != 0 {
+		var zero V
+		return zero, false
+	}
+
+	l.unlink(update, current)
+
+	return current.value, true
+}
+
+// seek returns the first node whose key is >= key, or nil if there is none
+func (l *List[K, V]) seek(key K) *node[K, V] {
+	return l.seekFunc(key, l.compare)
+}
+
+// seekFunc is seek ordering keys by compare, which readers use to count
+// their comparisons without writing to the list
+func (l *List[K, V]) seekFunc(key K, compare func(a, b K) int) *node[K, V] {
+	current := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward[i] != nil && compare(current.forward[i].key, key) < 0 {
+			current = current.forward[i]
+		}
+	}
+	return current.forward[0]
+}
+
+// find returns the node whose key equals key, or nil if there is none
+func (l *List[K, V]) find(key K) *node[K, V] {
+	return l.findFunc(key, l.compare)
+}
+
+// findFunc is find ordering keys by compare
+func (l *List[K, V]) findFunc(key K, compare func(a, b K) int) *node[K, V] {
+	if n := l.seekFunc(key, compare); n != nil && compare(n.key, key) == 0 {
+		return n
+	}
+	return nil
+}
+
+// Replace replaces the value stored under key and reports whether the key
+// was present; a missing key is not inserted
+func (l *List[K, V]) Replace(key K, value V) bool {
+	n := l.find(key)
+	if n == nil {
+		return false
+	}
+
+	n.value = value
+	l.order.touch(n)
+	return true
+}
+
+// Update replaces the value stored under key with fn applied to it and
+// reports whether the key was present
+func (l *List[K, V]) Update(key K, fn func(old V) V) bool {
+	n := l.find(key)
+	if n == nil {
+		return false
+	}
+
+	n.value = fn(n.value)
+	l.order.touch(n)
+	return true
+}
+
+// Len returns the length of the skip list
+func (l *List[K, V]) Len() int {
+	return l.length
+}
+
+// Clear removes all elements from the skip list
+func (l *List[K, V]) Clear() {
+	l.head.forward = make([]*node[K, V], l.opts.maxLevel)
+	l.head.span = make([]int, l.opts.maxLevel)
+	l.tail = nil
+	l.level = 1
+	l.length = 0
+	l.mods++
+
+	// Nodes detached by ClearIncremental are left to the garbage collector.
+	l.pending = nil
+	l.pendingTail = nil
+	l.pendingLen = 0
+
+	l.order.reset()
+}
+
+// Iterator returns a new iterator for the skip list.
+//
+// The iterator holds a pointer to its current node and reads the next one
+// only when it moves, so it never panics when the list changes underneath
+// it. Keys inserted or deleted ahead of it are seen. If its own node is
+// deleted, it goes on from the node that followed it at the time and
+// misses keys inserted before that one. After Clear it walks the nodes the
+// list held before; after ClearIncremental it stops after reaching a node
+// that was released, whose value is then the zero value. SnapshotIterator
+// gives a view that does not change.
+func (l *List[K, V]) Iterator() *ListIterator[K, V] {
+	return &ListIterator[K, V]{
+		list:   l,
+		node:   l.head,
+		isHead: true,
+	}
+}
+
+// ReverseIterator returns a new iterator for the skip list whose Next
+// walks from the largest key towards the smallest one; Prev walks back
+// towards larger keys
+func (l *List[K, V]) ReverseIterator() *ListIterator[K, V] {
+	it := l.Iterator()
+	it.reverse = true
+	return it
+}
+
+// Range returns an iterator over the keys in [min, max]. The first call
+// to Next moves to the first such key and Next returns false once the
+// iterator would pass max.
+func (l *List[K, V]) Range(min, max K) *ListIterator[K, V] {
+	it := l.Iterator()
+	it.setLower(min)
+	it.setUpper(max)
+	return it
+}
+
+// setLower restricts the iterator to keys >= key and positions it before
+// the first such key
+func (it *ListIterator[K, V]) setLower(key K) {
+	it.node = it.list.predecessor(key, false)
+	it.isHead = true
+	it.lower, it.hasLower = key, true
+}
+
+// setUpper restricts the iterator to keys <= key
+func (it *ListIterator[K, V]) setUpper(key K) {
+	it.upper, it.hasUpper = key, true
+}
+
+// inRange reports whether n is a node within the bounds of the iterator
+func (it *ListIterator[K, V]) inRange(n *node[K, V]) bool {
+	if n == nil {
+		return false
+	}
+	if it.hasLower && it.list.compare(n.key, it.lower) < 0 {
+		return false
+	}
+	if it.hasUpper && it.list.compare(n.key, it.upper) > 0 {
+		return false
+	}
+	return true
+}
+
+// Next moves the iterator to the next node in the skip list and returns true if successful
+func (it *ListIterator[K, V]) Next() bool {
+	if it.reverse {
+		return it.retreat()
+	}
+	return it.advance()
+}
+
+// Prev moves the iterator to the previous node in the skip list and returns
+// true if successful. At the first node, or before the first call to Next,
+// it returns false and leaves the iterator where it is.
+func (it *ListIterator[K, V]) Prev() bool {
+	if it.reverse {
+		return it.advance()
+	}
+	return it.retreat()
+}
+
+// advance moves the iterator to the next larger key
+func (it *ListIterator[K, V]) advance() bool {
+	if it.empty || it.node == nil || (it.reverse && it.isHead) || !it.inRange(it.node.forward[0]) {
+		return false
+	}
+	if it.list.opts.checkOrder && it.Valid() {
+		it.list.checkOrder(it.node, it.node.forward[0])
+	}
+	it.node = it.node.forward[0]
+	it.isHead = false
+	return true
+}
+
+// retreat moves the iterator to the next smaller key. A reverse iterator
+// that has not started yet moves to the last node.
+func (it *ListIterator[K, V]) retreat() bool {
+	if it.empty {
+		return false
+	}
+	if it.reverse && it.isHead {
+		if !it.inRange(it.list.tail) {
+			return false
+		}
+		it.node = it.list.tail
+		it.isHead = false
+		return true
+	}
+	if !it.Valid() || !it.inRange(it.node.backward) {
+		return false
+	}
+	if it.list.opts.checkOrder {
+		it.list.checkOrder(it.node.backward, it.node)
+	}
+	it.node = it.node.backward
+	return true
+}
+
+// Seek positions the iterator at the first node whose key is >= key and
+// returns true if there is one; Next then continues from that node. On a
+// range iterator a key below the lower bound seeks to the lower bound. If
+// there is none, the iterator is left exhausted and Next returns false.
+// An empty iterator stays empty.
+func (it *ListIterator[K, V]) Seek(key K) bool {
+	if it.empty {
+		return false
+	}
+	if it.hasLower && it.list.compare(key, it.lower) < 0 {
+		key = it.lower
+	}
+	it.node = it.list.seek(key)
+	it.isHead = false
+	if !it.inRange(it.node) {
+		it.node = nil
+	}
+	return it.node != nil
+}
+
+// SeekToLast positions the iterator at the largest key within its bounds
+// and returns true if there is one, found through the tail pointer or, for
+// a range with an upper bound, a descent to that bound. Prev then walks
+// towards smaller keys. If there is none, the iterator is left exhausted.
+// An empty iterator stays empty.
+func (it *ListIterator[K, V]) SeekToLast() bool {
+	if it.empty {
+		return false
+	}
+	it.node = it.list.tail
+	if it.hasUpper {
+		it.node = it.list.predecessor(it.upper, true)
+	}
+	it.isHead = false
+	if it.node == it.list.head || !it.inRange(it.node) {
+		it.node = nil
+	}
+	return it.node != nil
+}
+
+// Valid reports whether the iterator is positioned at a node
+func (it *ListIterator[K, V]) Valid() bool {
+	return !it.isHead && it.node != nil
+}
+
+// Key returns the key of the current node being iterated
+func (it *ListIterator[K, V]) Key() K {
+	if !it.Valid() {
+		var zero K
+		return zero
+	}
+	return it.node.key
+}
+
+// Value returns the value of the current node being iterated
+func (it *ListIterator[K, V]) Value() V {
+	if !it.Valid() {
+		var zero V
+		return zero
+	}
+	return it.node.value
+}
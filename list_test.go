@@ -0,0 +1,331 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRangeSeek(t *testing.T) {
+	l, err := NewList[int, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		l.Insert(i, i)
+	}
+
+	tests := []struct {
+		seek int
+		ok   bool
+		key  int
+	}{
+		{seek: 2, ok: true, key: 5},
+		{seek: 5, ok: true, key: 5},
+		{seek: 7, ok: true, key: 7},
+		{seek: 8, ok: true, key: 8},
+		{seek: 9, ok: false},
+	}
+	for _, tt := range tests {
+		it := l.Range(5, 8)
+		if ok := it.Seek(tt.seek); ok != tt.ok {
+			t.Fatalf("Range(5, 8).Seek(%d) = %v, want %v", tt.seek, ok, tt.ok)
+		}
+		if tt.ok && it.Key() != tt.key {
+			t.Fatalf("Range(5, 8).Seek(%d) stopped at %d, want %d", tt.seek, it.Key(), tt.key)
+		}
+	}
+
+	s := NewSkipList(Int)
+	for i := 0; i < 10; i++ {
+		s.Insert(i, i)
+	}
+	it := s.Range(5, 8)
+	if !it.Seek(2) || it.Key() != 5 {
+		t.Fatal("SkipList Range(5, 8).Seek(2) did not clamp to 5")
+	}
+	var keys []int
+	for ok := true; ok; ok = it.Next() {
+		keys = append(keys, it.Key().(int))
+	}
+	if len(keys) != 4 || keys[0] != 5 || keys[3] != 8 {
+		t.Fatalf("Iteration after clamped Seek yielded %v, want [5 6 7 8]", keys)
+	}
+}
+
+func TestInsertManySeeded(t *testing.T) {
+	l, err := NewList[int, int](WithRandSource(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(2))
+	want := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(1 << 20)
+		l.Insert(key, i)
+		want[key] = i
+	}
+
+	verify(t, l)
+	if l.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", l.Len(), len(want))
+	}
+	if l.level < 2 {
+		t.Fatalf("List of %d keys stayed at level %d", l.Len(), l.level)
+	}
+	last := -1
+	for key, value := range l.All() {
+		if key <= last {
+			t.Fatalf("All yielded %d after %d", key, last)
+		}
+		if want[key] != value {
+			t.Fatalf("Value of %d = %d, want %d", key, value, want[key])
+		}
+		last = key
+	}
+}
+
+func TestGetOrInsertSingleTraversal(t *testing.T) {
+	compares := 0
+	l, err := NewListFunc[int, int](func(a, b int) int {
+		compares++
+		return a - b
+	}, WithRandSource(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 4096
+	for _, key := range rand.New(rand.NewSource(2)).Perm(n) {
+		l.Insert(key*2, key)
+	}
+
+	// A lookup and a GetOrInsert of the same key follow the same path, so
+	// GetOrInsert may only add the equality check at the end of it.
+	total := 0
+	for key := -1; key <= 2*n; key++ {
+		compares = 0
+		l.Get(key)
+		lookup := compares
+
+		compares = 0
+		if _, loaded := l.GetOrInsert(key, -1); loaded != (key >= 0 && key < 2*n && key%2 == 0) {
+			t.Fatalf("GetOrInsert(%d) loaded = %t", key, loaded)
+		}
+		if compares > lookup+1 {
+			t.Fatalf("GetOrInsert(%d) made %d comparisons, a lookup %d", key, compares, lookup)
+		}
+		total += compares
+	}
+
+	verify(t, l)
+	if avg, bound := float64(total)/float64(2*n+2), 4*math.Log2(2*n); avg > bound {
+		t.Fatalf("GetOrInsert made %.1f comparisons on average, want at most %.1f", avg, bound)
+	}
+}
+
+func TestIteratorPrev(t *testing.T) {
+	compares := 0
+	l, _ := NewListFunc[int, int](func(a, b int) int {
+		compares++
+		return a - b
+	}, WithRandSource(rand.NewSource(1)))
+	rng := rand.New(rand.NewSource(2))
+	for _, key := range rng.Perm(500) {
+		l.Insert(key, key)
+	}
+	for key := 0; key < 500; key += 3 {
+		l.Delete(key)
+	}
+	verify(t, l)
+
+	it := l.Iterator()
+	if it.Prev() {
+		t.Fatal("Prev before the first Next moved")
+	}
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+
+	// Walking back follows the level-0 backward links without comparing keys.
+	it.SeekToLast()
+	compares = 0
+	for i := len(keys) - 1; i >= 0; i-- {
+		if !it.Valid() || it.Key() != keys[i] {
+			t.Fatalf("Prev reached %v, want %d", it.Key(), keys[i])
+		}
+		if it.Prev() != (i > 0) {
+			t.Fatalf("Prev at %d returned %t", keys[i], i == 0)
+		}
+	}
+	if compares != 0 {
+		t.Fatalf("Walking back over %d keys made %d comparisons, want 0", len(keys), compares)
+	}
+	if it.Key() != keys[0] {
+		t.Fatalf("Prev at the first key moved to %d", it.Key())
+	}
+
+	// Next and Prev undo each other.
+	it.Seek(250)
+	for i := 0; i < 20; i++ {
+		key := it.Key()
+		if !it.Next() || !it.Prev() || it.Key() != key {
+			t.Fatalf("Next then Prev from %d ended at %d", key, it.Key())
+		}
+		it.Next()
+	}
+}
+
+func TestComparatorPanic(t *testing.T) {
+	ops := map[string]func(l *List[int, int]){
+		"Insert":         func(l *List[int, int]) { l.Insert(101, -1) },
+		"Upsert":         func(l *List[int, int]) { l.Upsert(50, -1) },
+		"InsertIfAbsent": func(l *List[int, int]) { l.InsertIfAbsent(101, -1) },
+		"GetOrInsert":    func(l *List[int, int]) { l.GetOrInsert(101, -1) },
+		"Replace":        func(l *List[int, int]) { l.Replace(50, -1) },
+		"Update":         func(l *List[int, int]) { l.Update(50, func(int) int { return -1 }) },
+		"Delete":         func(l *List[int, int]) { l.Delete(50) },
+		"Remove":         func(l *List[int, int]) { l.Remove(50) },
+	}
+
+	var armed bool
+	var after int
+	for name, op := range ops {
+		for n := 0; n < 30; n++ {
+			l, _ := NewListFunc[int, int](panicky(&armed, &after), WithRandSource(rand.NewSource(int64(n))), WithInsertionOrder(true))
+			armed = false
+			for i := 0; i < 100; i += 2 {
+				l.Insert(i, i)
+			}
+
+			armed, after = true, n
+			panicked := mustPanic(func() { op(l) })
+			armed = false
+
+			verify(t, l)
+			if !panicked {
+				continue
+			}
+			if l.Len() != 50 {
+				t.Fatalf("%s: panic after %d comparisons left %d keys, want 50", name, n, l.Len())
+			}
+			for i := 0; i < 100; i += 2 {
+				if v, ok := l.Get(i); !ok || v != i {
+					t.Fatalf("%s: panic after %d comparisons changed %d to %v, %v", name, n, i, v, ok)
+				}
+			}
+		}
+	}
+}
+
+// readAllocs fails t unless Get, Contains, Floor and Ceiling of l make no
+// allocation for hit and miss
+func readAllocs[K any](t *testing.T, l *List[K, int], hit, miss K) {
+	t.Helper()
+	reads := map[string]func(key K){
+		"Get":      func(key K) { l.Get(key) },
+		"Contains": func(key K) { l.Contains(key) },
+		"Floor":    func(key K) { l.Floor(key) },
+		"Ceiling":  func(key K) { l.Ceiling(key) },
+	}
+	for name, read := range reads {
+		for _, key := range []K{hit, miss} {
+			if allocs := testing.AllocsPerRun(100, func() { read(key) }); allocs != 0 {
+				t.Errorf("%s(%v) made %v allocations, want 0", name, key, allocs)
+			}
+		}
+	}
+}
+
+func TestReadAllocs(t *testing.T) {
+	ints, err := NewList[int, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	strs, err := NewList[string, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i += 2 {
+		ints.Insert(i, i)
+		strs.Insert(fmt.Sprintf("key%04d", i), i)
+	}
+
+	readAllocs(t, ints, 500, 501)
+	readAllocs(t, strs, "key0500", "key0501")
+}
+
+// FuzzOperations runs a sequence of mutations decoded from the input on a
+// SkipList and on a map, then checks the structure of the list and that
+// both hold the same entries. Each pair of bytes is an operation and a key.
+func FuzzOperations(f *testing.F) {
+	f.Add([]byte{0, 5, 0, 3, 0, 9, 1, 3, 4, 0})
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 0, 4, 5, 2, 2, 9, 3, 0})
+	f.Add([]byte{6, 10, 6, 200, 7, 0, 5, 100})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := NewSkipList(Int)
+		model := make(map[int]int)
+		for i := 0; i+1 < len(data); i += 2 {
+			key := int(data[i+1])
+			switch data[i] % 8 {
+			case 0:
+				s.Insert(key, i)
+				model[key] = i
+			case 1:
+				s.Delete(key)
+				delete(model, key)
+			case 2:
+				s.Upsert(key, i)
+				model[key] = i
+			case 3:
+				if k, _, ok := s.PopMin(); ok {
+					delete(model, k.(int))
+				}
+			case 4:
+				if k, _, ok := s.PopMax(); ok {
+					delete(model, k.(int))
+				}
+			case 5:
+				// Removes the keys in [key, key+15]
+				s.DeleteRange(key, key+15)
+				for k := key; k <= key+15; k++ {
+					delete(model, k)
+				}
+			case 6:
+				// Merges the keys key, key+3, ... below 256 into the list
+				var keys, values []interface{}
+				for k := key; k < 256; k += 3 {
+					keys = append(keys, k)
+					values = append(values, i)
+					model[k] = i
+				}
+				if err := s.BulkInsert(keys, values); err != nil {
+					t.Fatal(err)
+				}
+			default:
+				s.Update(key, func(old interface{}) interface{} { return old.(int) + 1 })
+				if _, ok := model[key]; ok {
+					model[key]++
+				}
+			}
+		}
+
+		if err := s.CheckSorted(); err != nil {
+			t.Fatal(err)
+		}
+		verify(t, s.list)
+		if s.Length() != len(model) {
+			t.Fatalf("Length() = %d, want %d", s.Length(), len(model))
+		}
+		for key, value := range s.All() {
+			if want, ok := model[key.(int)]; !ok || want != value {
+				t.Fatalf("Key %v holds %v, want %v, %v", key, value, want, ok)
+			}
+		}
+	})
+}
@@ -0,0 +1,311 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// lfRef is an immutable forward reference of a lock-free node together with
+// the deletion mark of the node holding it. References are replaced as a
+// whole with compare-and-swap, so the pointer and the mark change atomically.
+type lfRef[K any, V any] struct {
+	node   *lfNode[K, V] // Next node at this level, nil at the end of the list
+	marked bool          // Whether the node holding this reference is logically deleted
+}
+
+// lfValue is an immutable value box of a lock-free node. Remove swaps in a
+// box with removed set, so an overwrite that compares and swaps the box
+// either lands before the removal or sees it and retries.
+type lfValue[V any] struct {
+	value   V    // Value of the node
+	removed bool // Whether the key was removed, which happens before the node is marked
+}
+
+// lfNode represents a node in the lock-free skip list
+type lfNode[K any, V any] struct {
+	key   K                             // Key of the node
+	value atomic.Pointer[lfValue[V]]    // Value of the node
+	next  []atomic.Pointer[lfRef[K, V]] // Forward references of the node
+}
+
+// LockFreeList is a skip list that is safe for concurrent use without locks.
+//
+// It follows the lock-free skip list of Herlihy and Shavit: Insert and
+// Delete link and unlink nodes with compare-and-swap, a node is deleted
+// logically by marking its forward references before it is unlinked, and
+// Get and Contains are wait-free. Remove takes a key out by swapping its
+// value box for a removed one before marking the node, and Insert replaces
+// a value by swapping the box too, so an overwrite either happens before a
+// concurrent Remove, which then returns the new value, or after it, and
+// inserts a new node. Length and iteration are weakly consistent while
+// writers are active.
+type LockFreeList[K any, V any] struct {
+	head    *lfNode[K, V]    // Head node of the skip list
+	length  atomic.Int64     // Number of nodes in the skip list
+	compare func(a, b K) int // Comparison function for the keys
+	opts    options          // Configuration of the skip list
+	rngMu   sync.Mutex       // Guards rng
+	rng     *rand.Rand       // Source of node levels set by WithRandSource, nil for the global one
+}
+
+// NewLockFreeList creates a new lock-free skip list ordered by the natural ordering of K
+func NewLockFreeList[K cmp.Ordered, V any](opts ...Option) (*LockFreeList[K, V], error) {
+	return NewLockFreeListFunc[K, V](cmp.Compare[K], opts...)
+}
+
+// NewLockFreeListFunc creates a new lock-free skip list ordered by the given comparison function
+func NewLockFreeListFunc[K any, V any](compare func(a, b K) int, opts ...Option) (*LockFreeList[K, V], error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &LockFreeList[K, V]{
+		head:    newLFNode[K, V](o.maxLevel),
+		compare: compare,
+		opts:    o,
+	}
+	if o.randSource != nil {
+		l.rng = rand.New(o.randSource)
+	}
+	return l, nil
+}
+
+// newLFNode creates a node with level forward references, all pointing to the end of the list
+func newLFNode[K any, V any](level int) *lfNode[K, V] {
+	n := &lfNode[K, V]{
+		next: make([]atomic.Pointer[lfRef[K, V]], level),
+	}
+	for i := range n.next {
+		n.next[i].Store(&lfRef[K, V]{})
+	}
+	return n
+}
+
+// casNext replaces the forward reference of n at level with (node, marked)
+// if it currently is (expected, expectedMark)
+func (n *lfNode[K, V]) casNext(level int, expected *lfNode[K, V], expectedMark bool, node *lfNode[K, V], marked bool) bool {
+	ref := n.next[level].Load()
+	if ref.node != expected || ref.marked != expectedMark {
+		return false
+	}
+	return n.next[level].CompareAndSwap(ref, &lfRef[K, V]{node: node, marked: marked})
+}
+
+// randomLevel generates a random level for the new node in the skip list.
+// A source set with WithRandSource is not safe for concurrent use, so
+// draws from it are serialized.
+func (l *LockFreeList[K, V]) randomLevel() int {
+	draw := rand.Float64
+	if l.rng != nil {
+		l.rngMu.Lock()
+		defer l.rngMu.Unlock()
+		draw = l.rng.Float64
+	}
+
+	level := 1
+	for level < l.opts.towerLimit() && draw() < l.opts.probability {
+		level++
+	}
+	return level
+}
+
+// mark marks every forward reference of n, top level first, so that find
+// unlinks it. It may be called by any goroutine once n has been removed.
+func (n *lfNode[K, V]) mark() {
+	for level := len(n.next) - 1; level >= 0; level-- {
+		ref := n.next[level].Load()
+		for !ref.marked {
+			n.casNext(level, ref.node, false, ref.node, true)
+			ref = n.next[level].Load()
+		}
+	}
+}
+
+// find fills preds and succs with the predecessors and successors of key at
+// every level, unlinking marked nodes on the way, and reports whether
+// succs[0] holds key
+func (l *LockFreeList[K, V]) find(key K, preds, succs []*lfNode[K, V]) bool {
+retry:
+	for {
+		pred := l.head
+		for level := l.opts.maxLevel - 1; level >= 0; level-- {
+			curr := pred.next[level].Load().node
+			for curr != nil {
+				ref := curr.next[level].Load()
+				if ref.marked {
+					if !pred.casNext(level, curr, false, ref.node, false) {
+						continue retry
+					}
+					curr = ref.node
+					continue
+				}
+				if l.compare(curr.key, key) >= 0 {
+					break
+				}
+				pred = curr
+				curr = ref.node
+			}
+			preds[level] = pred
+			succs[level] = curr
+		}
+		return succs[0] != nil && l.compare(succs[0].key, key) == 0
+	}
+}
+
+// lookup returns the unmarked node holding key, or nil if there is none.
+// It never writes to the list and never retries. The node may have been
+// removed but not yet marked, which its value box tells.
+func (l *LockFreeList[K, V]) lookup(key K) *lfNode[K, V] {
+	pred := l.head
+	var curr *lfNode[K, V]
+	for level := l.opts.maxLevel - 1; level >= 0; level-- {
+		curr = pred.next[level].Load().node
+		for curr != nil {
+			ref := curr.next[level].Load()
+			if ref.marked {
+				curr = ref.node
+				continue
+			}
+			if l.compare(curr.key, key) >= 0 {
+				break
+			}
+			pred = curr
+			curr = ref.node
+		}
+	}
+
+	if curr != nil && l.compare(curr.key, key) == 0 {
+		return curr
+	}
+	return nil
+}
+
+// Insert inserts a new key-value pair into the skip list, replacing the
+// value if the key is already present, and reports whether a node was created
+func (l *LockFreeList[K, V]) Insert(key K, value V) bool {
+	topLevel := l.randomLevel()
+	preds := make([]*lfNode[K, V], l.opts.maxLevel)
+	succs := make([]*lfNode[K, V], l.opts.maxLevel)
+
+	for {
+		if l.find(key, preds, succs) {
+			n := succs[0]
+			box := n.value.Load()
+			if !box.removed {
+				if n.value.CompareAndSwap(box, &lfValue[V]{value: value}) {
+					return false
+				}
+				continue
+			}
+			// The key was removed; finish marking the node so that find
+			// unlinks it, then insert a new one.
+			n.mark()
+			continue
+		}
+
+		newNode := newLFNode[K, V](topLevel)
+		newNode.key = key
+		newNode.value.Store(&lfValue[V]{value: value})
+		for level := 0; level < topLevel; level++ {
+			newNode.next[level].Store(&lfRef[K, V]{node: succs[level]})
+		}
+
+		if !preds[0].casNext(0, succs[0], false, newNode, false) {
+			continue
+		}
+		l.length.Add(1)
+
+		for level := 1; level < topLevel; level++ {
+			for {
+				ref := newNode.next[level].Load()
+				if ref.marked {
+					// Deleted before it was fully linked; stop building the tower.
+					return true
+				}
+				if ref.node != succs[level] && !newNode.next[level].CompareAndSwap(ref, &lfRef[K, V]{node: succs[level]}) {
+					continue
+				}
+				if preds[level].casNext(level, succs[level], false, newNode, false) {
+					break
+				}
+				l.find(key, preds, succs)
+			}
+		}
+
+		return true
+	}
+}
+
+// Get returns the value stored under key and whether the key was found
+func (l *LockFreeList[K, V]) Get(key K) (V, bool) {
+	if n := l.lookup(key); n != nil {
+		if box := n.value.Load(); !box.removed {
+			return box.value, true
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is present in the skip list
+func (l *LockFreeList[K, V]) Contains(key K) bool {
+	n := l.lookup(key)
+	return n != nil && !n.value.Load().removed
+}
+
+// Delete deletes a key from the skip list and reports whether it was present
+func (l *LockFreeList[K, V]) Delete(key K) bool {
+	_, ok := l.Remove(key)
+	return ok
+}
+
+// Remove deletes a key from the skip list and returns the value it held
+// and whether this call removed it
+func (l *LockFreeList[K, V]) Remove(key K) (V, bool) {
+	preds := make([]*lfNode[K, V], l.opts.maxLevel)
+	succs := make([]*lfNode[K, V], l.opts.maxLevel)
+
+	var zero V
+	if !l.find(key, preds, succs) {
+		return zero, false
+	}
+	victim := succs[0]
+
+	for {
+		box := victim.value.Load()
+		if box.removed {
+			// Another goroutine removed it first.
+			return zero, false
+		}
+		if victim.value.CompareAndSwap(box, &lfValue[V]{value: box.value, removed: true}) {
+			l.length.Add(-1)
+			victim.mark()
+			l.find(key, preds, succs)
+			return box.value, true
+		}
+	}
+}
+
+// Len returns the length of the skip list
+func (l *LockFreeList[K, V]) Len() int {
+	return int(l.length.Load())
+}
+
+// ForEach calls fn for each key-value pair in ascending key order until fn
+// returns false. Pairs inserted or deleted concurrently may or may not be visited.
+func (l *LockFreeList[K, V]) ForEach(fn func(key K, value V) bool) {
+	for curr := l.head.next[0].Load().node; curr != nil; {
+		ref := curr.next[0].Load()
+		if box := curr.value.Load(); !ref.marked && !box.removed && !fn(curr.key, box.value) {
+			return
+		}
+		curr = ref.node
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestLockFreeStress runs 8 writers against 32 readers. Each writer owns
+// the keys congruent to its index, so its own model tells what the list must
+// hold for them once every goroutine is done.
+func TestLockFreeStress(t *testing.T) {
+	const (
+		writers = 8
+		readers = 32
+		keys    = 1024
+		ops     = 4000
+	)
+	l, err := NewLockFreeList[int, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	models := make([]map[int]int, writers)
+	var stop atomic.Bool
+	var writing, reading sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		models[w] = make(map[int]int)
+		writing.Add(1)
+		go func(w int) {
+			defer writing.Done()
+			rng := rand.New(rand.NewSource(int64(w)))
+			model := models[w]
+			for i := 0; i < ops; i++ {
+				key := rng.Intn(keys/writers)*writers + w
+				if rng.Intn(3) == 0 {
+					_, present := model[key]
+					if l.Delete(key) != present {
+						t.Errorf("Delete(%d) disagreed with the model", key)
+						return
+					}
+					delete(model, key)
+					continue
+				}
+				_, present := model[key]
+				if l.Insert(key, i) == present {
+					t.Errorf("Insert(%d) disagreed with the model", key)
+					return
+				}
+				model[key] = i
+			}
+		}(w)
+	}
+	for r := 0; r < readers; r++ {
+		reading.Add(1)
+		go func(r int) {
+			defer reading.Done()
+			rng := rand.New(rand.NewSource(int64(writers + r)))
+			for !stop.Load() {
+				if rng.Intn(8) > 0 {
+					l.Get(rng.Intn(keys))
+					continue
+				}
+				last := -1
+				l.ForEach(func(key, value int) bool {
+					if key <= last {
+						t.Errorf("ForEach yielded %d after %d", key, last)
+						return false
+					}
+					last = key
+					return true
+				})
+			}
+		}(r)
+	}
+	writing.Wait()
+	stop.Store(true)
+	reading.Wait()
+
+	want := 0
+	for _, model := range models {
+		for key, value := range model {
+			if got, ok := l.Get(key); !ok || got != value {
+				t.Fatalf("Get(%d) = %d, %v, want %d, true", key, got, ok, value)
+			}
+		}
+		want += len(model)
+	}
+	count := 0
+	l.ForEach(func(int, int) bool {
+		count++
+		return true
+	})
+	if count != want || l.Len() != want {
+		t.Fatalf("ForEach visited %d keys and Len() = %d, want %d", count, l.Len(), want)
+	}
+}
+
+// lfEvent is one operation of a recorded history on a single key
+type lfEvent struct {
+	op    byte  // 'i' for Insert, 'r' for Remove, 'g' for Get
+	value int   // Value inserted, or value returned by Remove and Get
+	ok    bool  // Result of Insert, or whether Remove and Get found the key
+	call  int64 // Logical time the operation was invoked
+	ret   int64 // Logical time the operation returned
+}
+
+// linearizable reports whether the history of a single key can be ordered
+// sequentially, respecting real-time order, so that every result matches a
+// map. It is the search of Wing and Gong with memoization of visited states.
+func linearizable(history []lfEvent) bool {
+	type state struct {
+		done    uint64
+		present bool
+		value   int
+	}
+	all := uint64(1)<<len(history) - 1
+	seen := make(map[state]bool)
+
+	var search func(s state) bool
+	search = func(s state) bool {
+		if s.done == all {
+			return true
+		}
+		if seen[s] {
+			return false
+		}
+		seen[s] = true
+
+		minRet := int64(-1)
+		for i, e := range history {
+			if s.done&(1<<i) == 0 && (minRet < 0 || e.ret < minRet) {
+				minRet = e.ret
+			}
+		}
+		for i, e := range history {
+			if s.done&(1<<i) != 0 || e.call > minRet {
+				continue
+			}
+			next := state{done: s.done | 1<<i, present: s.present, value: s.value}
+			switch e.op {
+			case 'i':
+				if e.ok == s.present {
+					continue
+				}
+				next.present, next.value = true, e.value
+			case 'r':
+				if e.ok != s.present || (e.ok && e.value != s.value) {
+					continue
+				}
+				next.present, next.value = false, 0
+			case 'g':
+				if e.ok != s.present || (e.ok && e.value != s.value) {
+					continue
+				}
+			}
+			if search(next) {
+				return true
+			}
+		}
+		return false
+	}
+	return search(state{})
+}
+
+// TestLockFreeLinearizable records concurrent histories of Insert, Remove
+// and Get and checks the history of every key for linearizability. Keys are
+// independent, so the list is linearizable if every key is. Histories over
+// a single hot key race overwrites against removals.
+func TestLockFreeLinearizable(t *testing.T) {
+	lost := []lfEvent{
+		{op: 'i', value: 1, ok: true, call: 1, ret: 2},
+		{op: 'g', ok: false, call: 3, ret: 4},
+	}
+	if linearizable(lost) {
+		t.Fatal("The check accepted a Get missing a completed Insert")
+	}
+
+	for _, tc := range []struct{ workers, keys, ops, rounds int }{
+		{workers: 6, keys: 64, ops: 200, rounds: 5},
+		{workers: 4, keys: 1, ops: 16, rounds: 200},
+	} {
+		for round := 0; round < tc.rounds; round++ {
+			checkLinearizable(t, round, tc.workers, tc.keys, tc.ops)
+		}
+	}
+}
+
+// checkLinearizable runs workers goroutines doing ops random operations
+// each on keys keys, and fails t unless the history of every key is
+// linearizable
+func checkLinearizable(t *testing.T, round, workers, keys, ops int) {
+	t.Helper()
+	l, err := NewLockFreeList[int, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var clock atomic.Int64
+	histories := make([][][]lfEvent, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		histories[w] = make([][]lfEvent, keys)
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(round*workers + w)))
+			for i := 0; i < ops; i++ {
+				key := rng.Intn(keys)
+				e := lfEvent{call: clock.Add(1)}
+				switch rng.Intn(3) {
+				case 0:
+					e.op, e.value = 'i', w*ops+i+1
+					e.ok = l.Insert(key, e.value)
+				case 1:
+					e.op = 'r'
+					e.value, e.ok = l.Remove(key)
+				default:
+					e.op = 'g'
+					e.value, e.ok = l.Get(key)
+				}
+				e.ret = clock.Add(1)
+				histories[w][key] = append(histories[w][key], e)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for key := 0; key < keys; key++ {
+		var history []lfEvent
+		for w := range histories {
+			history = append(history, histories[w][key]...)
+		}
+		if len(history) > 64 {
+			t.Fatalf("History of key %d has %d operations, more than the check supports", key, len(history))
+		}
+		if !linearizable(history) {
+			t.Fatalf("Round %d: history of key %d is not linearizable: %+v", round, key, history)
+		}
+	}
+}
+
+func TestLockFreeRandSource(t *testing.T) {
+	// heights returns the tower height of every key after inserting keys
+	// 0 to 99 into a lock-free list seeded with seed
+	heights := func(seed int64) []int {
+		l, err := NewLockFreeList[int, int](WithRandSource(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 100; i++ {
+			l.Insert(i, i)
+		}
+		var h []int
+		for n := l.head.next[0].Load().node; n != nil; n = n.next[0].Load().node {
+			h = append(h, len(n.next))
+		}
+		return h
+	}
+
+	a, b := heights(1), heights(1)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("Lists with the same seed got heights %v and %v", a, b)
+	}
+	if reflect.DeepEqual(a, heights(2)) {
+		t.Fatal("Lists with different seeds got the same heights")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Merge moves every node of other into l in O(n+m) and leaves other empty.
+// The level-0 chains of both lists are walked together as in a merge sort
+// and every node keeps its tower while the levels are relinked left to
+// right. For a key present in both lists the node of l is kept, with the
+// value returned by onConflict when it is not nil. Keys taken from other
+// are appended to the insertion order of l in ascending key order.
+//
+// Every comparison and onConflict call happens in a first pass that only
+// records the merged order, so a panic in either leaves both lists as they were.
+func (l *List[K, V]) Merge(other *List[K, V], onConflict func(key K, a, b V) V) {
+	if other == nil || other == l || other.length == 0 {
+		return
+	}
+
+	// steps holds the sign of the comparison of the heads of both chains at
+	// every step of the merge, and resolved the values chosen for equal keys.
+	steps := make([]int8, 0, l.length+other.length)
+	var resolved []V
+	for a, b := l.head.forward[0], other.head.forward[0]; a != nil || b != nil; {
+		c := 0
+		if a == nil {
+			c = 1
+		} else if b != nil {
+			c = l.compare(a.key, b.key)
+		} else {
+			c = -1
+		}
+
+		switch {
+		case c < 0:
+			a = a.forward[0]
+			steps = append(steps, -1)
+		case c > 0:
+			b = b.forward[0]
+			steps = append(steps, 1)
+		default:
+			if onConflict != nil {
+				resolved = append(resolved, onConflict(a.key, a.value, b.value))
+			}
+			a, b = a.forward[0], b.forward[0]
+			steps = append(steps, 0)
+		}
+	}
+
+	last := make([]*node[K, V], len(l.head.forward))
+	lastRank := make([]int, len(l.head.forward))
+	for i := range last {
+		last[i] = l.head
+	}
+
+	a, b := l.head.forward[0], other.head.forward[0]
+	var prev *node[K, V]
+	length := 0
+	for _, step := range steps {
+		var n *node[K, V]
+		switch step {
+		case -1:
+			n, a = a, a.forward[0]
+		case 1:
+			n, b = b, b.forward[0]
+			l.order.push(n)
+		default:
+			if onConflict != nil {
+				a.value, resolved = resolved[0], resolved[1:]
+				l.order.touch(a)
+			}
+			n, a, b = a, a.forward[0], b.forward[0]
+		}
+
+		if len(n.forward) > len(last) {
+			n.forward = n.forward[:len(last):len(last)]
+			n.span = n.span[:len(last):len(last)]
+		}
+		if len(n.forward) > l.level {
+			l.level = len(n.forward)
+		}
+
+		length++
+		for i := range n.forward {
+			last[i].forward[i] = n
+			last[i].span[i] = length - lastRank[i]
+			last[i], lastRank[i] = n, length
+		}
+		n.backward = prev
+		prev = n
+	}
+
+	for i := range last {
+		last[i].forward[i] = nil
+		last[i].span[i] = length - lastRank[i]
+	}
+
+	l.tail = prev
+	l.length = length
+	l.mods++
+
+	other.Clear()
+}
+
+// Merge moves every key of other into s in O(n+m) and leaves other empty.
+// For a key present in both, onConflict receives the key, the value in s
+// and the value in other and returns the value to keep; a nil onConflict
+// keeps the value in s. Lists with different key types, or made without
+// key type but holding keys of different types, are rejected with
+// ErrKeyTypeMismatch and left unchanged.
+func (s *SkipList) Merge(other *SkipList, onConflict func(key, a, b interface{}) interface{}) error {
+	if other == nil {
+		return errors.New("Cannot merge a nil skip list")
+	}
+	if other == s {
+		return errors.New("Cannot merge a skip list into itself")
+	}
+	if s.keyType != other.keyType {
+		return fmt.Errorf("%w: expected %v, got %v", ErrKeyTypeMismatch, s.keyType, other.keyType)
+	}
+	if a, b := s.heldKeyType(), other.heldKeyType(); a != nil && b != nil && a != b {
+		return fmt.Errorf("%w: expected %v, got %v", ErrKeyTypeMismatch, a, b)
+	}
+
+	s.list.Merge(other.list, onConflict)
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 100; round++ {
+		a, _ := NewList[int, int](WithRandSource(rand.NewSource(int64(round))), WithInsertionOrder(true))
+		b, _ := NewList[int, int](WithRandSource(rand.NewSource(int64(-round))), WithMaxLevel(64))
+		want := make(map[int]int)
+		for _, key := range randomKeys(rng, rng.Intn(100), 300) {
+			a.Insert(key, key)
+			want[key] = key
+		}
+		for _, key := range randomKeys(rng, rng.Intn(100), 300) {
+			b.Insert(key, 1000)
+			want[key] += 1000
+		}
+
+		a.Merge(b, func(key, x, y int) int { return x + y })
+
+		verify(t, a)
+		verify(t, b)
+		if b.Len() != 0 {
+			t.Fatalf("Merge left %d keys in the other list", b.Len())
+		}
+		if a.Len() != len(want) {
+			t.Fatalf("Len = %d, want %d", a.Len(), len(want))
+		}
+		for key, value := range want {
+			if got, ok := a.Get(key); !ok || got != value {
+				t.Fatalf("Get(%d) = %d, %v, want %d", key, got, ok, value)
+			}
+		}
+	}
+}
+
+func TestMergeComparatorPanic(t *testing.T) {
+	var armed bool
+	var after int
+	for n := 0; n < 120; n += 3 {
+		a, _ := NewListFunc[int, int](panicky(&armed, &after), WithInsertionOrder(true))
+		b, _ := NewListFunc[int, int](panicky(&armed, &after))
+		armed = false
+		for i := 0; i < 50; i++ {
+			a.Insert(2*i, 0)
+			b.Insert(3*i, 1)
+		}
+
+		armed, after = true, n
+		panicked := mustPanic(func() { a.Merge(b, func(key, x, y int) int { return x + y }) })
+		armed = false
+
+		verify(t, a)
+		verify(t, b)
+		if panicked && (a.Len() != 50 || b.Len() != 50) {
+			t.Fatalf("Panic after %d comparisons left %d and %d keys, want 50 and 50", n, a.Len(), b.Len())
+		}
+		if !panicked && (a.Len() != 83 || b.Len() != 0) {
+			t.Fatalf("Merge left %d and %d keys, want 83 and 0", a.Len(), b.Len())
+		}
+	}
+}
+
+func TestSkipListMergeRejects(t *testing.T) {
+	s := NewSkipList(Int)
+	if err := s.Merge(nil, nil); err == nil {
+		t.Fatal("Merge accepted a nil list")
+	}
+	if err := s.Merge(s, nil); err == nil {
+		t.Fatal("Merge accepted the list itself")
+	}
+	if err := s.Merge(NewSkipList(String), nil); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("Merge of another key type: got %v, want ErrKeyTypeMismatch", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "slices"
+
+// MultiGet looks up every key in keys and returns the values and found
+// flags in the order of keys. The keys are visited in ascending order and
+// each lookup resumes from the search path of the previous one, so a batch
+// costs one pass over the list rather than a full descent per key.
+func (l *List[K, V]) MultiGet(keys []K) ([]V, []bool) {
+	values := make([]V, len(keys))
+	found := make([]bool, len(keys))
+
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return l.compare(keys[a], keys[b])
+	})
+
+	update := make([]*node[K, V], l.level)
+	for i := range update {
+		update[i] = l.head
+	}
+
+	for _, idx := range order {
+		key := keys[idx]
+
+		// Climb while the previous path falls short of key at this level;
+		// every level above the one reached still precedes key.
+		top := 0
+		for top < l.level-1 && update[top].forward[top] != nil && l.compare(update[top].forward[top].key, key) < 0 {
+			top++
+		}
+
+		current := update[top]
+		moved := false
+		for i := top; i >= 0; i-- {
+			if !moved && update[i] != current {
+				current = update[i]
+			}
+			for current.forward[i] != nil && l.compare(current.forward[i].key, key) < 0 {
+				current = current.forward[i]
+				moved = true
+			}
+			update[i] = current
+		}
+
+		if n := current.forward[0]; n != nil && l.compare(n.key, key) == 0 {
+			values[idx] = n.value
+			found[idx] = true
+		}
+	}
+
+	return values, found
+}
+
+// MultiGet looks up a batch of keys in a single pass over the skip list
+// and returns the value of every key that was found. Missing keys are
+// absent from the result. A nil or mismatched key rejects the whole batch
+// with ErrNilKey or ErrKeyTypeMismatch. MultiGet is not subject to the
+// comparison budget.
+func (s *SkipList) MultiGet(keys []interface{}) (map[interface{}]interface{}, error) {
+	for _, key := range keys {
+		if err := s.checkKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	values, found := s.list.MultiGet(keys)
+
+	result := make(map[interface{}]interface{}, len(keys))
+	for i, key := range keys {
+		if !found[i] {
+			continue
+		}
+		result[key] = values[i]
+		if s.access != nil {
+			s.access.record(key)
+		}
+	}
+	return result, nil
+}
+
+// MultiGet looks up a batch of keys in a single pass over the skip list
+// and returns the value of every key that was found
+func (c *ConcurrentSkipList) MultiGet(keys []interface{}) (map[interface{}]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.MultiGet(keys)
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "testing"
+
+// BenchmarkMultiGet resolves a sorted batch of 1000 keys, half of them
+// present, with one MultiGet or with a loop of Search calls, in a list of
+// 1M keys
+func BenchmarkMultiGet(b *testing.B) {
+	const n = 1000000
+	keys, values := sortedEntries(n, 0, 2)
+	s, err := NewFromSorted(Int, keys, values)
+	if err != nil {
+		b.Fatal(err)
+	}
+	batch := make([]interface{}, 1000)
+	for i := range batch {
+		batch[i] = 500000 + i
+	}
+
+	b.Run("MultiGet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result, err := s.MultiGet(batch)
+			if err != nil || len(result) != len(batch)/2 {
+				b.Fatalf("MultiGet found %d keys, %v", len(result), err)
+			}
+		}
+	})
+	b.Run("Search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result := make(map[interface{}]interface{}, len(batch))
+			for _, key := range batch {
+				if value, err := s.Search(key); err == nil {
+					result[key] = value
+				}
+			}
+			if len(result) != len(batch)/2 {
+				b.Fatalf("Search found %d keys", len(result))
+			}
+		}
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// Namespaces keeps one skip list per namespace behind a single API, so that
+// a whole namespace can be dropped without visiting its keys. A namespace
+// is created by its first write. Like SkipList, it is not safe for
+// concurrent use.
+type Namespaces struct {
+	keyType reflect.Type         // Key type of every namespace
+	opts    []Option             // Options every namespace is created with
+	spaces  map[string]*SkipList // Skip list of each namespace
+}
+
+// NewNamespaces creates an empty set of namespaces whose skip lists use
+// the given key type and options. WithRecorder is rejected: every
+// namespace would write a recording of its own to the same writer, and a
+// single list replaying them could not tell the namespaces apart.
+func NewNamespaces(keyType reflect.Type, opts ...Option) (*Namespaces, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if o.recorder != nil {
+		return nil, errors.New("Namespaces cannot record operations")
+	}
+
+	return &Namespaces{
+		keyType: keyType,
+		opts:    opts,
+		spaces:  make(map[string]*SkipList),
+	}, nil
+}
+
+// Put inserts or updates a key in namespace ns, creating the namespace if
+// it does not exist yet. A rejected key does not create the namespace.
+func (n *Namespaces) Put(ns string, key, value interface{}) error {
+	s, ok := n.spaces[ns]
+	if !ok {
+		var err error
+		if s, err = New(n.keyType, n.opts...); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Insert(key, value); err != nil {
+		return err
+	}
+	n.spaces[ns] = s
+
+	return nil
+}
+
+// Get returns the value stored under key in namespace ns and whether it was found
+func (n *Namespaces) Get(ns string, key interface{}) (interface{}, bool) {
+	s, ok := n.spaces[ns]
+	if !ok {
+		return nil, false
+	}
+	return s.Get(key)
+}
+
+// Delete removes key from namespace ns. The namespace is kept even when it
+// becomes empty. A missing namespace or key yields ErrKeyNotFound.
+func (n *Namespaces) Delete(ns string, key interface{}) error {
+	s, ok := n.spaces[ns]
+	if !ok {
+		return fmt.Errorf("%w: %v in namespace %q", ErrKeyNotFound, key, ns)
+	}
+	return s.Delete(key)
+}
+
+// DropNamespace removes namespace ns and all of its keys in O(1) and
+// reports whether it existed
+func (n *Namespaces) DropNamespace(ns string) bool {
+	if _, ok := n.spaces[ns]; !ok {
+		return false
+	}
+	delete(n.spaces, ns)
+	return true
+}
+
+// Namespace returns the skip list of namespace ns and whether it exists.
+// Changes made through it are visible through n.
+func (n *Namespaces) Namespace(ns string) (*SkipList, bool) {
+	s, ok := n.spaces[ns]
+	return s, ok
+}
+
+// Names returns the names of the namespaces in ascending order
+func (n *Namespaces) Names() []string {
+	names := make([]string, 0, len(n.spaces))
+	for ns := range n.spaces {
+		names = append(names, ns)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Len returns the number of keys in namespace ns, 0 if it does not exist
+func (n *Namespaces) Len(ns string) int {
+	if s, ok := n.spaces[ns]; ok {
+		return s.Length()
+	}
+	return 0
+}
+
+// All returns an iterator over the entries of every namespace, ordered by
+// namespace and then by key, for use with range
+func (n *Namespaces) All() iter.Seq2[string, Entry] {
+	return func(yield func(string, Entry) bool) {
+		for _, ns := range n.Names() {
+			s, ok := n.spaces[ns]
+			if !ok {
+				continue
+			}
+			for key, value := range s.All() {
+				if !yield(ns, Entry{Key: key, Value: value}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stats returns a snapshot of the level structure of namespace ns and
+// whether the namespace exists
+func (n *Namespaces) Stats(ns string) (Stats, bool) {
+	s, ok := n.spaces[ns]
+	if !ok {
+		return Stats{}, false
+	}
+	return s.Stats(), true
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// predecessor returns the rightmost node whose key is < key (or <= key if
+// inclusive), which is the head node when there is none
+func (l *List[K, V]) predecessor(key K, inclusive bool) *node[K, V] {
+	current := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward[i] != nil {
+			c := l.compare(current.forward[i].key, key)
+			if c > 0 || (c == 0 && !inclusive) {
+				break
+			}
+			current = current.forward[i]
+		}
+	}
+	return current
+}
+
+// entry returns the key and value of n and whether n is a data node
+func (l *List[K, V]) entry(n *node[K, V]) (K, V, bool) {
+	if n == nil || n == l.head {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+// First returns the smallest key in the skip list, with its value, in O(1)
+func (l *List[K, V]) First() (K, V, bool) {
+	return l.entry(l.head.forward[0])
+}
+
+// Last returns the largest key in the skip list, with its value, in O(1)
+func (l *List[K, V]) Last() (K, V, bool) {
+	return l.entry(l.tail)
+}
+
+// Floor returns the largest key less than or equal to key, with its value
+func (l *List[K, V]) Floor(key K) (K, V, bool) {
+	return l.entry(l.predecessor(key, true))
+}
+
+// Ceiling returns the smallest key greater than or equal to key, with its value
+func (l *List[K, V]) Ceiling(key K) (K, V, bool) {
+	return l.entry(l.seek(key))
+}
+
+// Lower returns the largest key strictly less than key, with its value
+func (l *List[K, V]) Lower(key K) (K, V, bool) {
+	return l.entry(l.predecessor(key, false))
+}
+
+// Higher returns the smallest key strictly greater than key, with its value
+func (l *List[K, V]) Higher(key K) (K, V, bool) {
+	return l.entry(l.predecessor(key, true).forward[0])
+}
+
+// First returns the smallest key in the skip list, with its value, in O(1)
+func (s *SkipList) First() (interface{}, interface{}, bool) {
+	return s.list.First()
+}
+
+// Last returns the largest key in the skip list, with its value, in O(1)
+func (s *SkipList) Last() (interface{}, interface{}, bool) {
+	return s.list.Last()
+}
+
+// Min returns the smallest key in the skip list, with its value, in O(1).
+// Unlike MinInt and MinString it relies only on the order of the list, so
+// it works for every key type. It is the same as First.
+func (s *SkipList) Min() (interface{}, interface{}, bool) {
+	return s.list.First()
+}
+
+// Max returns the largest key in the skip list, with its value, in O(1)
+// from the tail of level 0. It works for every key type and is the same
+// as Last.
+func (s *SkipList) Max() (interface{}, interface{}, bool) {
+	return s.list.Last()
+}
+
+// Floor returns the largest key less than or equal to key, with its value
+func (s *SkipList) Floor(key interface{}) (interface{}, interface{}, bool) {
+	if s.checkKey(key) != nil {
+		return nil, nil, false
+	}
+	return s.list.Floor(key)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, with its value
+func (s *SkipList) Ceiling(key interface{}) (interface{}, interface{}, bool) {
+	if s.checkKey(key) != nil {
+		return nil, nil, false
+	}
+	return s.list.Ceiling(key)
+}
+
+// Lower returns the largest key strictly less than key, with its value.
+// The key itself is excluded even when present in the skip list.
+func (s *SkipList) Lower(key interface{}) (interface{}, interface{}, bool) {
+	if s.checkKey(key) != nil {
+		return nil, nil, false
+	}
+	return s.list.Lower(key)
+}
+
+// Higher returns the smallest key strictly greater than key, with its value.
+// The key itself is excluded even when present in the skip list.
+func (s *SkipList) Higher(key interface{}) (interface{}, interface{}, bool) {
+	if s.checkKey(key) != nil {
+		return nil, nil, false
+	}
+	return s.list.Higher(key)
+}
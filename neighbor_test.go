@@ -0,0 +1,110 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestHigherLower(t *testing.T) {
+	s := NewSkipList(Int)
+	for _, key := range []int{30, 10, 20} {
+		s.Insert(key, key*10)
+	}
+
+	tests := []struct {
+		key          int
+		lower, upper interface{} // Expected keys of Lower and Higher, nil for none
+	}{
+		{5, nil, 10},
+		{10, nil, 20}, // the minimum
+		{15, 10, 20},
+		{20, 10, 30},
+		{30, 20, nil}, // the maximum
+		{35, 30, nil},
+	}
+	for _, tt := range tests {
+		key, value, ok := s.Lower(tt.key)
+		if key != tt.lower || ok != (tt.lower != nil) || (ok && value != key.(int)*10) {
+			t.Errorf("Lower(%d) = %v, %v, %t, want %v", tt.key, key, value, ok, tt.lower)
+		}
+		key, value, ok = s.Higher(tt.key)
+		if key != tt.upper || ok != (tt.upper != nil) || (ok && value != key.(int)*10) {
+			t.Errorf("Higher(%d) = %v, %v, %t, want %v", tt.key, key, value, ok, tt.upper)
+		}
+	}
+
+	empty := NewSkipList(Int)
+	if _, _, ok := empty.Lower(1); ok {
+		t.Error("Lower on an empty list found a key")
+	}
+	if _, _, ok := empty.Higher(1); ok {
+		t.Error("Higher on an empty list found a key")
+	}
+	if _, _, ok := s.Higher("a"); ok {
+		t.Error("Higher of a mismatched key found a key")
+	}
+}
+
+func TestHigherLowerRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	l, _ := NewList[int, int](WithRandSource(rand.NewSource(2)))
+	keys := randomKeys(rng, 300, 1000)
+	for _, i := range rng.Perm(len(keys)) {
+		l.Insert(keys[i], keys[i])
+	}
+	verify(t, l)
+
+	for key := -1; key <= 1001; key++ {
+		at, found := slices.BinarySearch(keys, key)
+		lower, _, ok := l.Lower(key)
+		if want := at > 0; ok != want || (ok && lower != keys[at-1]) {
+			t.Fatalf("Lower(%d) = %d, %t", key, lower, ok)
+		}
+		if found {
+			at++
+		}
+		higher, _, ok := l.Higher(key)
+		if want := at < len(keys); ok != want || (ok && higher != keys[at]) {
+			t.Fatalf("Higher(%d) = %d, %t", key, higher, ok)
+		}
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	l, _ := NewList[int, int](WithRandSource(rand.NewSource(1)))
+	if _, _, ok := l.First(); ok {
+		t.Fatal("First found a key in an empty list")
+	}
+	if _, _, ok := l.Last(); ok {
+		t.Fatal("Last found a key in an empty list")
+	}
+
+	rng := rand.New(rand.NewSource(2))
+	keys := randomKeys(rng, 200, 10000)
+	rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	for i, key := range keys {
+		l.Insert(key, -key)
+		lo, hi := slices.Min(keys[:i+1]), slices.Max(keys[:i+1])
+		if k, v, ok := l.First(); !ok || k != lo || v != -lo {
+			t.Fatalf("After %d inserts First() = %d, %d, %t, want %d", i+1, k, v, ok, lo)
+		}
+		if k, v, ok := l.Last(); !ok || k != hi || v != -hi {
+			t.Fatalf("After %d inserts Last() = %d, %d, %t, want %d", i+1, k, v, ok, hi)
+		}
+	}
+
+	// Deleting the extremes moves First and Last inward.
+	slices.Sort(keys)
+	l.Delete(keys[0])
+	l.Delete(keys[len(keys)-1])
+	if k, _, _ := l.First(); k != keys[1] {
+		t.Errorf("First() after deleting the minimum = %d, want %d", k, keys[1])
+	}
+	if k, _, _ := l.Last(); k != keys[len(keys)-2] {
+		t.Errorf("Last() after deleting the maximum = %d, want %d", k, keys[len(keys)-2])
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// maxLevelLimit is the largest accepted maximum level. Even at the default
+// probability a list needs 2^64 nodes before a 64th level pays off.
+const maxLevelLimit = 64
+
+// DefaultProbability is the default probability of promoting a node to the next level
+var DefaultProbability = 0.5
+
+// options holds the construction-time configuration of a skip list
+type options struct {
+	maxLevel     int         // Maximum level of the skip list
+	maxNodeLevel int         // Maximum level of a single node, 0 for maxLevel
+	probability  float64     // Probability of promoting a node to the next level
+	randSource   rand.Source // Source of randomness for node levels, nil for a time-seeded one
+	maxCompares  int         // Maximum comparisons per SkipList operation, 0 for unlimited
+	checkOrder   bool        // Whether iterators verify that keys are strictly increasing
+	strict       bool        // Whether SkipList reports duplicate keys, inverted ranges and seeks past the end as errors
+
+	insertionOrder  bool // Whether nodes are also chained in insertion order
+	moveOnOverwrite bool // Whether replacing a value moves the node to the newest end of that chain
+
+	accessTopK   int                                    // Number of most read keys a SkipList tracks, 0 to count no reads
+	valueEqual   func(a, b interface{}) bool            // Equality of values for SkipList compare-and-swap, nil for reflect.DeepEqual
+	valueDecoder func(data []byte) (interface{}, error) // Decoder of JSON values for SkipList, nil for json.Unmarshal
+	keyCodec     KeyCodec                               // Binary codec of keys for Encode and Decode, nil for the built-in one
+	valueCodec   ValueCodec                             // Binary codec of values for Encode and Decode, nil for the built-in one
+	recorder     io.Writer                              // Destination of the operations a SkipList records, nil to record none
+	valueCloner  func(v interface{}) interface{}        // Copier of values for Clone, Split, SnapshotIterator and Compile, nil to share them
+}
+
+// Option configures a skip list at construction time
+type Option func(*options) error
+
+// defaultOptions returns the configuration used when no option is given
+func defaultOptions() options {
+	return options{
+		maxLevel:    DefaultMaxLevel,
+		probability: DefaultProbability,
+	}
+}
+
+// newOptions applies opts on top of the default configuration
+func newOptions(opts []Option) (options, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return o, err
+		}
+	}
+	return o, nil
+}
+
+// towerLimit returns the maximum level a new node may get
+func (o options) towerLimit() int {
+	if o.maxNodeLevel > 0 {
+		return min(o.maxNodeLevel, o.maxLevel)
+	}
+	return o.maxLevel
+}
+
+// WithMaxLevel sets the maximum level of the skip list. It must be between 1 and 64.
+// The head node allocates this many forward pointers up front.
+func WithMaxLevel(n int) Option {
+	return func(o *options) error {
+		if n < 1 || n > maxLevelLimit {
+			return fmt.Errorf("Max level must be between 1 and %d, got %d", maxLevelLimit, n)
+		}
+		o.maxLevel = n
+		return nil
+	}
+}
+
+// WithMaxNodeLevel caps the tower of every node at n levels, independently
+// of the maximum level of the list, so that no single Delete touches more
+// than n pointers. Since the list never rises above its tallest node, the
+// cap also bounds the levels searches can use: a list of N keys keeps about
+// N*p^(n-1) of them on its top level, where p is the promotion probability,
+// so once N grows well past (1/p)^n searches slow down linearly with N.
+// It must be between 1 and 64.
+func WithMaxNodeLevel(n int) Option {
+	return func(o *options) error {
+		if n < 1 || n > maxLevelLimit {
+			return fmt.Errorf("Max node level must be between 1 and %d, got %d", maxLevelLimit, n)
+		}
+		o.maxNodeLevel = n
+		return nil
+	}
+}
+
+// WithProbability sets the probability of promoting a node to the next level.
+// It must be in the open interval (0, 1); the default is DefaultProbability.
+//
+// A node reaches level i with probability p^i, so a list of n keys uses
+// about log_{1/p}(n) levels and 1/(1-p) pointers per node, and a search
+// makes about log_{1/p}(n)/p steps. A smaller p such as 0.25 saves memory
+// for a slightly longer search. The max level should be at least
+// log_{1/p}(n) for the largest expected n, or the top level fills up and
+// searches degrade towards a linear scan.
+func WithProbability(p float64) Option {
+	return func(o *options) error {
+		if !(p > 0 && p < 1) {
+			return fmt.Errorf("Probability must be in (0, 1), got %v", p)
+		}
+		o.probability = p
+		return nil
+	}
+}
+
+// WithRandSource sets the source of randomness used to pick node levels.
+// Each list otherwise gets its own source seeded from the current time, so
+// lists never contend on the global math/rand lock; passing a seeded source
+// makes level assignment reproducible. The source is only used by the list
+// it is given to and must not be shared with other goroutines. LockFreeList
+// serializes its draws from the source, since its inserts pick levels
+// concurrently.
+func WithRandSource(src rand.Source) Option {
+	return func(o *options) error {
+		if src == nil {
+			return fmt.Errorf("Rand source cannot be nil")
+		}
+		o.randSource = src
+		return nil
+	}
+}
+
+// WithMaxComparisons limits the number of key comparisons a single Insert,
+// Upsert, GetOrInsert, InsertIfAbsent, Replace, Update, Search, Get,
+// Contains, Delete, Remove, CompareAndSwap or CompareAndDelete on a SkipList
+// may make. An operation over the limit is aborted with ErrBudgetExceeded,
+// or reports a miss for methods without an error result, and leaves the
+// list unchanged.
+// A healthy list needs a small multiple of log2(n) comparisons, so hitting
+// the limit also points at a slow comparator or a degenerate structure.
+// The default of 0 means unlimited.
+func WithMaxComparisons(n int) Option {
+	return func(o *options) error {
+		if n < 0 {
+			return fmt.Errorf("Max comparisons cannot be negative, got %d", n)
+		}
+		o.maxCompares = n
+		return nil
+	}
+}
+
+// WithIterationChecks makes every iterator verify that each key it moves to
+// is strictly greater (or, moving backwards, strictly less) than the one it
+// left, and panic naming both keys otherwise. It is a debugging aid that
+// costs one extra comparison per step.
+func WithIterationChecks() Option {
+	return func(o *options) error {
+		o.checkOrder = true
+		return nil
+	}
+}
+
+// WithStrict makes a SkipList report conditions it otherwise handles
+// silently: Insert of a key that is already present fails with
+// ErrDuplicateKey and leaves the stored value untouched, range methods
+// treat a start greater than end as invalid bounds, so RangeLimit,
+// CountRange, DeleteRange and ExplainRange fail with ErrInvertedRange
+// instead of returning nothing and the Err of a Range iterator reports it,
+// and the Err of an iterator whose Seek finds no key reports ErrSeekPastEnd.
+// It is meant to catch misuse during development; the default is lenient.
+func WithStrict() Option {
+	return func(o *options) error {
+		o.strict = true
+		return nil
+	}
+}
+
+// WithInsertionOrder chains the nodes of the skip list in the order they
+// were inserted as well as in key order, for queries such as RecentN and
+// OldestN. If moveOnOverwrite is set, replacing the value of a key counts
+// as inserting it again; otherwise the key keeps its original position.
+// The chain is kept in two pointers of every node, which lists created
+// without this option leave unset.
+func WithInsertionOrder(moveOnOverwrite bool) Option {
+	return func(o *options) error {
+		o.insertionOrder = true
+		o.moveOnOverwrite = moveOnOverwrite
+		return nil
+	}
+}
+
+// WithValueCloner makes Clone, SnapshotIterator and Compile pass every
+// value they copy through fn, and Split pass every value it moves, so that
+// lists holding pointers, slices or maps do not share them with their
+// copies. Without it values are copied by reference. CloneWith uses its
+// own function instead. fn must return a value of the value type of the
+// list, or nil for the zero value.
+func WithValueCloner(fn func(v interface{}) interface{}) Option {
+	return func(o *options) error {
+		if fn == nil {
+			return fmt.Errorf("Value cloner cannot be nil")
+		}
+		o.valueCloner = fn
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(s *SkipList) error
+		want error
+	}{
+		{"Insert duplicate", func(s *SkipList) error {
+			return s.Insert(5, "again")
+		}, ErrDuplicateKey},
+		{"InsertAt duplicate", func(s *SkipList) error {
+			_, _, pos := s.SearchForInsert(5)
+			_, err := s.InsertAt(pos, 5, "again")
+			return err
+		}, ErrDuplicateKey},
+		{"RangeLimit inverted", func(s *SkipList) error {
+			_, _, err := s.RangeLimit(8, 2, 0)
+			return err
+		}, ErrInvertedRange},
+		{"CountRange inverted", func(s *SkipList) error {
+			_, err := s.CountRange(8, 2)
+			return err
+		}, ErrInvertedRange},
+		{"DeleteRange inverted", func(s *SkipList) error {
+			_, err := s.DeleteRange(8, 2)
+			return err
+		}, ErrInvertedRange},
+		{"ExplainRange inverted", func(s *SkipList) error {
+			_, err := s.ExplainRange(8, 2)
+			return err
+		}, ErrInvertedRange},
+		{"Range inverted", func(s *SkipList) error {
+			it := s.Range(8, 2)
+			if it.Next() {
+				return errors.New("Inverted range yielded a key")
+			}
+			return it.Err()
+		}, ErrInvertedRange},
+		{"Seek past the end", func(s *SkipList) error {
+			it := s.Iterator()
+			if it.Seek(10) {
+				return errors.New("Seek past the end found a key")
+			}
+			return it.Err()
+		}, ErrSeekPastEnd},
+		{"Seek past a range", func(s *SkipList) error {
+			it := s.Range(2, 4)
+			if it.Seek(5) {
+				return errors.New("Seek past the range found a key")
+			}
+			return it.Err()
+		}, ErrSeekPastEnd},
+	}
+
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			var opts []Option
+			if strict {
+				opts = append(opts, WithStrict())
+			}
+			s, err := New(Int, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 10; i++ {
+				if err := s.Insert(i, i); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = tt.op(s)
+			switch {
+			case strict && !errors.Is(err, tt.want):
+				t.Errorf("%s in strict mode: got %v, want %v", tt.name, err, tt.want)
+			case !strict && err != nil:
+				t.Errorf("%s in lenient mode: got %v, want nil", tt.name, err)
+			}
+			if s.Length() != 10 {
+				t.Errorf("%s with strict=%v: Length() = %d, want 10", tt.name, strict, s.Length())
+			}
+			if v, _ := s.Get(5); strict && v != 5 {
+				t.Errorf("%s in strict mode replaced the value of 5 with %v", tt.name, v)
+			}
+		}
+	}
+
+	// An error is cleared by the next Seek that finds a key.
+	s, err := New(Int, WithStrict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Insert(1, 1)
+	it := s.Iterator()
+	it.Seek(2)
+	if !it.Seek(0) || it.Err() != nil {
+		t.Fatalf("Seek after a miss: Err() = %v, want nil", it.Err())
+	}
+}
+
+func TestMaxNodeLevel(t *testing.T) {
+	const clamp = 3
+	// A high probability makes towers above the clamp the rule.
+	opts := []Option{WithMaxNodeLevel(clamp), WithProbability(0.9), WithRandSource(rand.NewSource(1))}
+
+	l, err := NewList[int, int](opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Insert slows down towards a linear scan with so few levels.
+	for i := 0; i < 5000; i++ {
+		l.Insert(i, i)
+	}
+	if st := l.Stats(); st.Level != clamp || st.Nodes[clamp-1] == 0 {
+		t.Fatalf("Insert built %d levels with %v nodes, want %d levels all in use", st.Level, st.Nodes, clamp)
+	}
+
+	keys := make([]interface{}, 1<<20)
+	for i := range keys {
+		keys[i] = i
+	}
+	s, err := NewFromSorted(Int, keys, keys, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := s.Stats(); st.Level != clamp {
+		t.Fatalf("NewFromSorted of %d keys built %d levels, want %d", len(keys), st.Level, clamp)
+	}
+
+	lf, _ := NewLockFreeList[int, int](opts...)
+	for i := 0; i < 5000; i++ {
+		lf.Insert(i, i)
+	}
+	for n := lf.head.next[0].Load().node; n != nil; n = n.next[0].Load().node {
+		if len(n.next) > clamp {
+			t.Fatalf("LockFreeList node %d has %d levels, want at most %d", n.key, len(n.next), clamp)
+		}
+	}
+
+	for _, n := range []int{0, -1, maxLevelLimit + 1} {
+		if _, err := NewList[int, int](WithMaxNodeLevel(n)); err == nil {
+			t.Errorf("WithMaxNodeLevel(%d) was accepted", n)
+		}
+	}
+}
+
+func TestProbability(t *testing.T) {
+	for _, p := range []float64{0, 1, -0.5, 1.5, math.NaN(), math.Inf(1)} {
+		if _, err := NewList[int, int](WithProbability(p)); err == nil {
+			t.Errorf("WithProbability(%v) was accepted", p)
+		}
+	}
+
+	// Each level holds about p of the nodes of the level below.
+	for _, p := range []float64{0.25, 0.5, 0.75} {
+		l, err := NewList[int, int](WithProbability(p), WithRandSource(rand.NewSource(1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 100000; i++ {
+			l.Insert(i, i)
+		}
+		st := l.Stats()
+		if ratio := float64(st.Nodes[1]) / float64(st.Nodes[0]); math.Abs(ratio-p) > 0.02 {
+			t.Errorf("WithProbability(%v) promoted %.3f of the nodes", p, ratio)
+		}
+	}
+}
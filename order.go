@@ -0,0 +1,159 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "iter"
+
+// insertionOrder chains the nodes of a list in the order they were
+// inserted, through the older and newer links of the nodes. The links are
+// only maintained while a list has an insertionOrder, that is when it was
+// created with WithInsertionOrder. Every method is a no-op on a nil
+// *insertionOrder.
+type insertionOrder[K any, V any] struct {
+	oldest          *node[K, V] // First node of the chain
+	newest          *node[K, V] // Last node of the chain
+	moveOnOverwrite bool        // Whether replacing a value makes the node the newest
+}
+
+// newInsertionOrder returns an empty insertion-order chain
+func newInsertionOrder[K any, V any](moveOnOverwrite bool) *insertionOrder[K, V] {
+	return &insertionOrder[K, V]{moveOnOverwrite: moveOnOverwrite}
+}
+
+// push appends n as the newest node
+func (o *insertionOrder[K, V]) push(n *node[K, V]) {
+	if o == nil {
+		return
+	}
+
+	n.older, n.newer = o.newest, nil
+	if o.newest != nil {
+		o.newest.newer = n
+	} else {
+		o.oldest = n
+	}
+	o.newest = n
+}
+
+// remove takes n off the chain
+func (o *insertionOrder[K, V]) remove(n *node[K, V]) {
+	if o == nil {
+		return
+	}
+
+	if n.older != nil {
+		n.older.newer = n.newer
+	} else {
+		o.oldest = n.newer
+	}
+	if n.newer != nil {
+		n.newer.older = n.older
+	} else {
+		o.newest = n.older
+	}
+	n.older, n.newer = nil, nil
+}
+
+// touch records that the value of n was replaced
+func (o *insertionOrder[K, V]) touch(n *node[K, V]) {
+	if o == nil || !o.moveOnOverwrite || o.newest == n {
+		return
+	}
+	o.remove(n)
+	o.push(n)
+}
+
+// reset empties the chain
+func (o *insertionOrder[K, V]) reset() {
+	if o == nil {
+		return
+	}
+	o.oldest = nil
+	o.newest = nil
+}
+
+// Oldest returns an iterator over the key-value pairs of the skip list
+// from the earliest inserted to the latest. It yields nothing unless the
+// list was created with WithInsertionOrder.
+func (l *List[K, V]) Oldest() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if l.order == nil {
+			return
+		}
+		for n := l.order.oldest; n != nil; n = n.newer {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Newest returns an iterator over the key-value pairs of the skip list
+// from the latest inserted to the earliest. It yields nothing unless the
+// list was created with WithInsertionOrder.
+func (l *List[K, V]) Newest() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if l.order == nil {
+			return
+		}
+		for n := l.order.newest; n != nil; n = n.older {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// firstN collects at most n pairs from seq
+func firstN(seq iter.Seq2[interface{}, interface{}], n int) []Entry {
+	var entries []Entry
+	if n <= 0 {
+		return entries
+	}
+	for key, value := range seq {
+		entries = append(entries, Entry{Key: key, Value: value})
+		if len(entries) == n {
+			break
+		}
+	}
+	return entries
+}
+
+// RecentN returns the n most recently inserted entries, newest first.
+// It returns nothing unless the list was created with WithInsertionOrder.
+func (s *SkipList) RecentN(n int) []Entry {
+	return firstN(s.list.Newest(), n)
+}
+
+// OldestN returns the n earliest inserted entries, oldest first.
+// It returns nothing unless the list was created with WithInsertionOrder.
+func (s *SkipList) OldestN(n int) []Entry {
+	return firstN(s.list.Oldest(), n)
+}
+
+// Oldest returns an iterator over the entries of the skip list from the
+// earliest inserted to the latest
+func (s *SkipList) Oldest() iter.Seq2[interface{}, interface{}] {
+	return s.list.Oldest()
+}
+
+// Newest returns an iterator over the entries of the skip list from the
+// latest inserted to the earliest
+func (s *SkipList) Newest() iter.Seq2[interface{}, interface{}] {
+	return s.list.Newest()
+}
+
+// RecentN returns the n most recently inserted entries, newest first
+func (c *ConcurrentSkipList) RecentN(n int) []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.RecentN(n)
+}
+
+// OldestN returns the n earliest inserted entries, oldest first
+func (c *ConcurrentSkipList) OldestN(n int) []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.OldestN(n)
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInsertionOrderMoveOnOverwrite(t *testing.T) {
+	overwrites := map[string]func(l *List[int, int]){
+		"Upsert":  func(l *List[int, int]) { l.Upsert(1, 10) },
+		"Replace": func(l *List[int, int]) { l.Replace(1, 10) },
+		"Update":  func(l *List[int, int]) { l.Update(1, func(old int) int { return old * 10 }) },
+	}
+	for name, overwrite := range overwrites {
+		l, err := NewList[int, int](WithInsertionOrder(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, k := range []int{1, 2, 3} {
+			l.Insert(k, k)
+		}
+
+		overwrite(l)
+		var keys []int
+		for k := range l.Oldest() {
+			keys = append(keys, k)
+		}
+		if want := []int{2, 3, 1}; !slices.Equal(keys, want) {
+			t.Errorf("%s: Oldest yielded %v, want %v", name, keys, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// PopMin removes the smallest key from the skip list and returns it with
+// its value. The head precedes the first node at every level, so no
+// search is needed.
+func (l *List[K, V]) PopMin() (K, V, bool) {
+	first := l.head.forward[0]
+	if first == nil {
+		return l.entry(nil)
+	}
+
+	update := make([]*node[K, V], l.level)
+	for i := range update {
+		update[i] = l.head
+	}
+	l.unlink(update, first)
+
+	return first.key, first.value, true
+}
+
+// PopMax removes the largest key from the skip list and returns it with
+// its value. Its predecessors are found by descending towards the tail,
+// which compares pointers rather than keys.
+func (l *List[K, V]) PopMax() (K, V, bool) {
+	last := l.tail
+	if last == nil {
+		return l.entry(nil)
+	}
+
+	update := make([]*node[K, V], l.level)
+	current := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward[i] != nil && current.forward[i] != last {
+			current = current.forward[i]
+		}
+		update[i] = current
+	}
+	l.unlink(update, last)
+
+	return last.key, last.value, true
+}
+
+// PopMin removes the smallest key from the skip list and returns it with its value
+func (s *SkipList) PopMin() (interface{}, interface{}, bool) {
+	key, value, ok := s.list.PopMin()
+	if ok {
+		s.rec.recordOp(opPopMin)
+	}
+	return key, value, ok
+}
+
+// PopMax removes the largest key from the skip list and returns it with its value
+func (s *SkipList) PopMax() (interface{}, interface{}, bool) {
+	key, value, ok := s.list.PopMax()
+	if ok {
+		s.rec.recordOp(opPopMax)
+	}
+	return key, value, ok
+}
+
+// PopMin removes the smallest key from the skip list and returns it with its value
+func (c *ConcurrentSkipList) PopMin() (interface{}, interface{}, bool) {
+	c.lockFor(func() *keyRange {
+		if key, _, ok := c.list.First(); ok {
+			return &keyRange{start: key, end: key}
+		}
+		return nil
+	})
+	defer c.mu.Unlock()
+	return c.list.PopMin()
+}
+
+// PopMax removes the largest key from the skip list and returns it with its value
+func (c *ConcurrentSkipList) PopMax() (interface{}, interface{}, bool) {
+	c.lockFor(func() *keyRange {
+		if key, _, ok := c.list.Last(); ok {
+			return &keyRange{start: key, end: key}
+		}
+		return nil
+	})
+	defer c.mu.Unlock()
+	return c.list.PopMax()
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPopMinMax(t *testing.T) {
+	compares := 0
+	l, _ := NewListFunc[int, int](func(a, b int) int {
+		compares++
+		return a - b
+	}, WithRandSource(rand.NewSource(1)))
+	for _, key := range rand.New(rand.NewSource(2)).Perm(500) {
+		l.Insert(key, -key)
+	}
+
+	// Alternate ends until the list is empty; neither end compares keys.
+	lo, hi := 0, 499
+	for l.Len() > 0 {
+		popMin := l.Len()%2 == 0
+		var key, value int
+		var ok bool
+		want := hi
+		compares = 0
+		if popMin {
+			key, value, ok = l.PopMin()
+			want = lo
+			lo++
+		} else {
+			key, value, ok = l.PopMax()
+			hi--
+		}
+		if compares != 0 {
+			t.Fatalf("Pop (min %t) made %d comparisons, want 0", popMin, compares)
+		}
+		if !ok || key != want || value != -want {
+			t.Fatalf("Pop (min %t) = %d, %d, %t, want %d", popMin, key, value, ok, want)
+		}
+		if l.Len() != hi-lo+1 {
+			t.Fatalf("Len() = %d after popping %d, want %d", l.Len(), key, hi-lo+1)
+		}
+		if l.Contains(key) {
+			t.Fatalf("Popped key %d is still present", key)
+		}
+		verify(t, l)
+	}
+	if l.level != 1 {
+		t.Errorf("Level of the emptied list = %d, want 1", l.level)
+	}
+
+	if _, _, ok := l.PopMin(); ok {
+		t.Error("PopMin on an empty list found a key")
+	}
+	if _, _, ok := l.PopMax(); ok {
+		t.Error("PopMax on an empty list found a key")
+	}
+}
+
+func TestPopOrder(t *testing.T) {
+	s := NewSkipList(Int)
+	for _, key := range rand.New(rand.NewSource(1)).Perm(100) {
+		s.Insert(key, nil)
+	}
+	for want := 0; want < 50; want++ {
+		if key, _, ok := s.PopMin(); !ok || key != want {
+			t.Fatalf("PopMin() = %v, %t, want %d", key, ok, want)
+		}
+	}
+	for want := 99; want >= 50; want-- {
+		if key, _, ok := s.PopMax(); !ok || key != want {
+			t.Fatalf("PopMax() = %v, %t, want %d", key, ok, want)
+		}
+	}
+	if s.Length() != 0 {
+		t.Fatalf("Len() = %d after popping every key", s.Length())
+	}
+}
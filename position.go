@@ -0,0 +1,116 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "fmt"
+
+// Position records where a key sits or would be inserted in a skip list,
+// as returned by SearchForInsert. It stays fresh until the next insert or
+// delete of any key; replacing values does not make it stale.
+type Position[K any, V any] struct {
+	list   *List[K, V]   // Skip list the position belongs to
+	mods   uint64        // Structural changes of the list when the position was taken
+	key    K             // Key that was searched for
+	update []*node[K, V] // Rightmost node before key at every level
+	rank   []int         // Number of nodes up to and including each node in update
+	node   *node[K, V]   // Node holding key, nil if it was missing
+}
+
+// InsertPosition is a Position in a SkipList
+type InsertPosition = Position[interface{}, interface{}]
+
+// SearchForInsert looks up key like Get and also returns its position, so
+// that a following InsertAt of the same key needs no second descent
+func (l *List[K, V]) SearchForInsert(key K) (V, bool, Position[K, V]) {
+	pos := Position[K, V]{
+		list:   l,
+		mods:   l.mods,
+		key:    key,
+		update: make([]*node[K, V], len(l.head.forward)),
+		rank:   make([]int, len(l.head.forward)),
+	}
+
+	if n := l.path(key, pos.update, pos.rank); n != nil && l.compare(n.key, key) == 0 {
+		pos.node = n
+		return n.value, true, pos
+	}
+
+	var zero V
+	return zero, false, pos
+}
+
+// fresh reports whether pos was taken for key on l and is still valid
+func (l *List[K, V]) fresh(pos Position[K, V], key K) bool {
+	return pos.list == l && pos.mods == l.mods && l.compare(pos.key, key) == 0
+}
+
+// InsertAt inserts key and value at pos, or replaces the value if key was
+// present, without descending again. It reports false and changes nothing
+// if pos is stale or was taken for another key or list.
+func (l *List[K, V]) InsertAt(pos Position[K, V], key K, value V) bool {
+	if !l.fresh(pos, key) {
+		return false
+	}
+
+	if pos.node != nil {
+		pos.node.value = value
+		l.order.touch(pos.node)
+		return true
+	}
+
+	l.link(pos.update, pos.rank, key, value)
+	return true
+}
+
+// SearchForInsert looks up key like Get and also returns its position for
+// a following InsertAt of the same key. A nil or mismatched key, or a
+// lookup over the comparison budget, is reported as not found with a
+// position that InsertAt treats as stale.
+func (s *SkipList) SearchForInsert(key interface{}) (interface{}, bool, InsertPosition) {
+	if s.checkKey(key) != nil {
+		return nil, false, InsertPosition{}
+	}
+
+	var value interface{}
+	var found bool
+	var pos InsertPosition
+	if s.withBudget(func() {
+		value, found, pos = s.list.SearchForInsert(key)
+	}) != nil {
+		return nil, false, InsertPosition{}
+	}
+	if found {
+		s.rec.record(opGet, key, nil)
+	}
+
+	return value, found, pos
+}
+
+// InsertAt inserts key and value at a position returned by SearchForInsert
+// for the same key, splicing the node in without descending again. If the
+// skip list has gained or lost keys since, the position is stale and InsertAt
+// falls back to Insert. It reports whether the position was used. Errors
+// are those of Insert, including ErrDuplicateKey in strict mode.
+func (s *SkipList) InsertAt(pos InsertPosition, key, value interface{}) (bool, error) {
+	if err := s.checkKey(key); err != nil {
+		return false, err
+	}
+
+	var fresh bool
+	if err := s.withBudget(func() {
+		fresh = s.list.fresh(pos, key)
+	}); err != nil {
+		return false, err
+	}
+	if !fresh {
+		return false, s.Insert(key, value)
+	}
+
+	if s.list.opts.strict && pos.node != nil {
+		return true, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+	}
+	s.list.InsertAt(pos, key, value)
+	s.rec.record(opInsert, key, value)
+	return true, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// DefaultRangeLimit is the number of entries returned by RangeLimit
+// when it is called with a limit <= 0
+var DefaultRangeLimit = 1000
+
+// Entry represents a key-value pair stored in the skip list
+type Entry struct {
+	Key   interface{} // Key of the entry
+	Value interface{} // Value of the entry
+}
+
+// RangeLimit returns at most limit entries with start <= key <= end in
+// ascending key order. A nil start means from the first key and a nil end
+// means up to the last key. A limit <= 0 means DefaultRangeLimit.
+//
+// When more entries remain in the range, nextKey is the key of the first
+// entry that was not returned; passing it as start to a following call
+// resumes the scan. Otherwise nextKey is nil. Bounds that fail CheckRange
+// yield its error, except that inverted bounds yield no entries outside
+// strict mode.
+func (s *SkipList) RangeLimit(start, end interface{}, limit int) ([]Entry, interface{}, error) {
+	if limit <= 0 {
+		limit = DefaultRangeLimit
+	}
+
+	if err := s.checkBounds(start, end); err != nil {
+		return nil, nil, err
+	}
+
+	current := s.list.head.forward[0]
+	if start != nil {
+		current = s.list.seek(start)
+	}
+
+	var entries []Entry
+	for current != nil {
+		if end != nil && s.compare(current.key, end) > 0 {
+			break
+		}
+		if len(entries) == limit {
+			return entries, current.key, nil
+		}
+		entries = append(entries, Entry{Key: current.key, Value: current.value})
+		current = current.forward[0]
+	}
+
+	return entries, nil, nil
+}
+
+// RangeQuery returns all entries with min <= key <= max in ascending key
+// order, with the same bound rules as Range. An inverted range yields no entries.
+func (s *SkipList) RangeQuery(min, max interface{}) []Entry {
+	var entries []Entry
+
+	it := s.Range(min, max)
+	for it.Next() {
+		entries = append(entries, Entry{Key: it.Key(), Value: it.Value()})
+	}
+
+	return entries
+}
+
+// CountRange returns the number of keys with min <= key <= max in
+// O(log n), as the difference of two ranks
+func (l *List[K, V]) CountRange(min, max K) int {
+	below, _ := l.locate(min)
+	if upTo := l.countUpTo(max); upTo > below {
+		return upTo - below
+	}
+	return 0
+}
+
+// CountRange returns the number of keys with start <= key <= end in
+// O(log n) without visiting them. A nil start means from the first key and
+// a nil end means up to the last key. Bounds that fail CheckRange yield its
+// error, except that inverted bounds count nothing outside strict mode.
+func (s *SkipList) CountRange(start, end interface{}) (int, error) {
+	if err := s.checkBounds(start, end); err != nil {
+		return 0, err
+	}
+
+	below, upTo := 0, s.list.length
+	if start != nil {
+		below, _ = s.list.locate(start)
+	}
+	if end != nil {
+		upTo = s.list.countUpTo(end)
+	}
+	return max(upTo-below, 0), nil
+}
+
+// deleteRange unlinks every node within the bounds of it and returns how
+// many were removed. Both ends of the range are located at every level
+// before any pointer is modified, so a panicking comparison function
+// leaves the list unchanged.
+func (l *List[K, V]) deleteRange(it *ListIterator[K, V]) int {
+	update := make([]*node[K, V], l.level)
+	rank := make([]int, l.level)
+	current, traversed := l.head, 0
+	for i := l.level - 1; i >= 0; i-- {
+		if it.hasLower {
+			for current.forward[i] != nil && l.compare(current.forward[i].key, it.lower) < 0 {
+				traversed += current.span[i]
+				current = current.forward[i]
+			}
+		}
+		update[i], rank[i] = current, traversed
+	}
+
+	last := make([]*node[K, V], l.level)
+	lastRank := make([]int, l.level)
+	current, traversed = l.head, 0
+	for i := l.level - 1; i >= 0; i-- {
+		// The last node in range is never before the node preceding the range.
+		if rank[i] > traversed {
+			current, traversed = update[i], rank[i]
+		}
+		for current.forward[i] != nil && (!it.hasUpper || l.compare(current.forward[i].key, it.upper) <= 0) {
+			traversed += current.span[i]
+			current = current.forward[i]
+		}
+		last[i], lastRank[i] = current, traversed
+	}
+
+	removed := lastRank[0] - rank[0]
+	if removed == 0 {
+		return 0
+	}
+
+	for n, end := update[0].forward[0], last[0].forward[0]; n != end; n = n.forward[0] {
+		l.order.remove(n)
+	}
+
+	for i := 0; i < l.level; i++ {
+		update[i].span[i] = lastRank[i] + last[i].span[i] - rank[i] - removed
+		update[i].forward[i] = last[i].forward[i]
+	}
+
+	prev := update[0]
+	if prev == l.head {
+		prev = nil
+	}
+	if next := update[0].forward[0]; next != nil {
+		next.backward = prev
+	} else {
+		l.tail = prev
+	}
+
+	for l.level > 1 && l.head.forward[l.level-1] == nil {
+		l.level--
+	}
+
+	l.length -= removed
+	l.mods++
+
+	return removed
+}
+
+// DeleteRange removes every key in [min, max] and returns how many were removed
+func (l *List[K, V]) DeleteRange(min, max K) int {
+	return l.deleteRange(l.Range(min, max))
+}
+
+// DeleteRange removes every key in [start, end] in a single sweep and
+// returns how many were removed. A nil start means from the first key and
+// a nil end means up to the last key. Bounds that fail CheckRange yield its
+// error, except that inverted bounds remove nothing outside strict mode.
+func (s *SkipList) DeleteRange(start, end interface{}) (int, error) {
+	if err := s.checkBounds(start, end); err != nil {
+		return 0, err
+	}
+
+	it := s.Range(start, end)
+	removed := 0
+	if !it.it.empty {
+		removed = s.list.deleteRange(it.it)
+	}
+	s.rec.recordRange(start, end)
+	return removed, nil
+}
+
+// DeleteRange removes every key in [start, end] in a single sweep and
+// returns how many were removed
+func (c *ConcurrentSkipList) DeleteRange(start, end interface{}) (int, error) {
+	c.lockRange(start, end)
+	defer c.mu.Unlock()
+	return c.list.DeleteRange(start, end)
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDeleteRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		l, _ := NewList[int, int](WithRandSource(rand.NewSource(int64(round))), WithInsertionOrder(false))
+		var model []int
+		for i := 0; i < 100; i++ {
+			key := rng.Intn(200)
+			if !slices.Contains(model, key) {
+				model = append(model, key)
+			}
+			l.Insert(key, key)
+		}
+		slices.Sort(model)
+
+		min, max := rng.Intn(220)-10, rng.Intn(220)-10
+		want := slices.DeleteFunc(slices.Clone(model), func(k int) bool { return k >= min && k <= max })
+
+		if got := l.DeleteRange(min, max); got != len(model)-len(want) {
+			t.Fatalf("DeleteRange(%d, %d) = %d, want %d", min, max, got, len(model)-len(want))
+		}
+		verify(t, l)
+		if got := slices.Collect(l.Keys()); !slices.Equal(got, want) {
+			t.Fatalf("DeleteRange(%d, %d) left %v, want %v", min, max, got, want)
+		}
+	}
+}
+
+func TestDeleteRangeComparatorPanic(t *testing.T) {
+	var armed bool
+	var after int
+	for n := 0; n < 60; n++ {
+		l, _ := NewListFunc[int, int](panicky(&armed, &after), WithRandSource(rand.NewSource(int64(n))), WithInsertionOrder(false))
+		armed = false
+		for i := 0; i < 200; i++ {
+			l.Insert(i, i)
+		}
+
+		armed, after = true, n
+		panicked := mustPanic(func() { l.DeleteRange(50, 149) })
+		armed = false
+
+		verify(t, l)
+		if panicked && l.Len() != 200 {
+			t.Fatalf("Panic after %d comparisons left %d keys, want 200", n, l.Len())
+		}
+		if !panicked && l.Len() != 100 {
+			t.Fatalf("DeleteRange left %d keys, want 100", l.Len())
+		}
+	}
+}
+
+// rangeKeys collects the keys visited by Range(min, max)
+func rangeKeys(s *SkipList, min, max interface{}) []int {
+	var keys []int
+	for it := s.Range(min, max); it.Next(); {
+		keys = append(keys, it.Key().(int))
+	}
+	return keys
+}
+
+func TestRange(t *testing.T) {
+	s := NewSkipList(Int)
+	for key := 10; key <= 50; key += 10 {
+		s.Insert(key, key)
+	}
+
+	tests := []struct {
+		min, max interface{}
+		want     []int
+	}{
+		{nil, nil, []int{10, 20, 30, 40, 50}},
+		{25, nil, []int{30, 40, 50}},
+		{nil, 25, []int{10, 20}},
+		{20, 40, []int{20, 30, 40}}, // both bounds are inclusive
+		{30, 30, []int{30}},
+		{15, 25, []int{20}},
+		{21, 29, nil}, // between two keys
+		{31, 31, nil},
+		{0, 5, nil},   // below the first key
+		{55, 99, nil}, // above the last key
+		{-100, 100, []int{10, 20, 30, 40, 50}},
+		{40, 20, nil}, // inverted
+	}
+	for _, tt := range tests {
+		if got := rangeKeys(s, tt.min, tt.max); !slices.Equal(got, tt.want) {
+			t.Errorf("Range(%v, %v) = %v, want %v", tt.min, tt.max, got, tt.want)
+		}
+	}
+
+	if got := rangeKeys(NewSkipList(Int), nil, nil); got != nil {
+		t.Errorf("Range over an empty list = %v", got)
+	}
+}
+
+func TestRangeLimit(t *testing.T) {
+	s := NewSkipList(Int)
+	for key := 0; key < 2500; key++ {
+		s.Insert(key, -key)
+	}
+
+	// Paging by the resume key visits every key in the bounds exactly once.
+	var got []int
+	var start interface{} = 100
+	for pages := 0; start != nil; pages++ {
+		if pages > 100 {
+			t.Fatal("RangeLimit did not finish paging")
+		}
+		entries, next, err := s.RangeLimit(start, 1999, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) > 64 || (next != nil && len(entries) != 64) {
+			t.Fatalf("RangeLimit(%v, 1999, 64) returned %d entries with next %v", start, len(entries), next)
+		}
+		for _, e := range entries {
+			if e.Value != -e.Key.(int) {
+				t.Fatalf("Entry %v has the wrong value", e)
+			}
+			got = append(got, e.Key.(int))
+		}
+		if next != nil && next != got[len(got)-1]+1 {
+			t.Fatalf("Next key %v does not follow %d", next, got[len(got)-1])
+		}
+		start = next
+	}
+	want := make([]int, 0, 1900)
+	for key := 100; key <= 1999; key++ {
+		want = append(want, key)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Paging covered %d keys from %d, want 100 to 1999", len(got), got[0])
+	}
+
+	// A limit <= 0 means DefaultRangeLimit.
+	for _, limit := range []int{0, -1} {
+		entries, next, err := s.RangeLimit(nil, nil, limit)
+		if err != nil || len(entries) != DefaultRangeLimit || next != DefaultRangeLimit {
+			t.Errorf("RangeLimit(nil, nil, %d) = %d entries, next %v, %v, want %d", limit, len(entries), next, err, DefaultRangeLimit)
+		}
+	}
+
+	// A range that fits the limit exactly has nothing left.
+	if entries, next, _ := s.RangeLimit(10, 19, 10); len(entries) != 10 || next != nil {
+		t.Errorf("RangeLimit(10, 19, 10) = %d entries, next %v, want 10, nil", len(entries), next)
+	}
+	if entries, next, _ := s.RangeLimit(3000, nil, 10); len(entries) != 0 || next != nil {
+		t.Errorf("RangeLimit past the last key = %v, %v", entries, next)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// keyRange is an inclusive key range, open on the side of a nil bound
+type keyRange struct {
+	start interface{} // Smallest key of the range, nil for no lower bound
+	end   interface{} // Largest key of the range, nil for no upper bound
+}
+
+// wholeRange is the range of every key, written by Clear
+var wholeRange = &keyRange{}
+
+// overlaps reports whether r and o share at least one key
+func (r *keyRange) overlaps(o *keyRange) bool {
+	if r.end != nil && o.start != nil && compareOrdered(r.end, o.start) < 0 {
+		return false
+	}
+	if o.end != nil && r.start != nil && compareOrdered(o.end, r.start) < 0 {
+		return false
+	}
+	return true
+}
+
+// rangeLocks is a table of held key ranges. Waiters sleep on released,
+// which is closed and replaced whenever a range is given back.
+type rangeLocks struct {
+	mu       sync.Mutex             // Guards held and released
+	held     map[*keyRange]struct{} // Ranges currently held
+	released chan struct{}          // Closed when a range is released, nil if nobody waits
+}
+
+// conflict returns nil if r overlaps no held range, and otherwise a channel
+// that is closed once a range is released. t.mu must be held.
+func (t *rangeLocks) conflict(r *keyRange) chan struct{} {
+	for h := range t.held {
+		if h.overlaps(r) {
+			if t.released == nil {
+				t.released = make(chan struct{})
+			}
+			return t.released
+		}
+	}
+	return nil
+}
+
+// wait returns nil if r overlaps no held range, and otherwise a channel
+// that is closed once a range is released
+func (t *rangeLocks) wait(r *keyRange) chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.conflict(r)
+}
+
+// acquire blocks until r overlaps no held range and then holds it, or
+// returns the error of ctx if it is done first
+func (t *rangeLocks) acquire(ctx context.Context, r *keyRange) error {
+	for {
+		t.mu.Lock()
+		released := t.conflict(r)
+		if released == nil {
+			if t.held == nil {
+				t.held = make(map[*keyRange]struct{})
+			}
+			t.held[r] = struct{}{}
+			t.mu.Unlock()
+			return nil
+		}
+		t.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release gives r back and wakes every waiter to check its range again
+func (t *rangeLocks) release(r *keyRange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.held, r)
+	if t.released != nil {
+		close(t.released)
+		t.released = nil
+	}
+}
+
+// lockFor takes the write lock of the skip list once the keys written by
+// the caller overlap no range held through LockRange. target is called
+// under the lock, so it may read the list; a nil range conflicts with
+// nothing, which lets invalid keys through to fail as usual.
+func (c *ConcurrentSkipList) lockFor(target func() *keyRange) {
+	for {
+		c.mu.Lock()
+		r := target()
+		if r == nil {
+			return
+		}
+		released := c.ranges.wait(r)
+		if released == nil {
+			return
+		}
+		c.mu.Unlock()
+		<-released
+	}
+}
+
+// lockKey takes the write lock once key lies in no range held through LockRange
+func (c *ConcurrentSkipList) lockKey(key interface{}) {
+	c.lockFor(func() *keyRange {
+		if c.list.checkKey(key) != nil {
+			return nil
+		}
+		return &keyRange{start: key, end: key}
+	})
+}
+
+// lockRange takes the write lock once [start, end] overlaps no range held
+// through LockRange
+func (c *ConcurrentSkipList) lockRange(start, end interface{}) {
+	c.lockFor(func() *keyRange {
+		if c.list.CheckRange(start, end) != nil {
+			return nil
+		}
+		return &keyRange{start: start, end: end}
+	})
+}
+
+// RangeLock is a key range held through LockRange. Other writers to keys
+// in the range block until Unlock is called, so the holder writes to the
+// range through the methods of the RangeLock, which accept keys within it
+// only and fail with ErrRangeUnlocked once it is unlocked. It is meant for
+// use by a single goroutine.
+type RangeLock struct {
+	c        *ConcurrentSkipList // Skip list the range belongs to
+	r        *keyRange           // Range held
+	unlocked atomic.Bool         // Whether Unlock has been called
+}
+
+// LockRange blocks until no other caller holds a range overlapping
+// [start, end] and then holds it until Unlock is called on the returned
+// RangeLock. A nil bound leaves that side of the range open. Ranges that do
+// not overlap are held at the same time, so callers working on disjoint key
+// ranges do not wait for each other's Unlock.
+//
+// The range table only decides who may write where. The skip list itself
+// is not safe for concurrent writers, so every write, through a RangeLock
+// or not, still takes the write lock of the whole list for its duration,
+// and writes to disjoint ranges are serialized one operation at a time.
+// What a range buys is exclusive use of its keys across many operations.
+//
+// While the range is held, every write method of the skip list touching a
+// key in it, including PopMin, PopMax and DeleteRange when they would
+// reach into it, and Clear, Split and ClearIncremental, waits for Unlock.
+// Reads are not blocked and see the writes of the holder as they happen.
+// The holder writes through the RangeLock; calling the write methods of the
+// skip list for a key in its own range blocks forever.
+//
+// A caller holding one range and waiting for another can deadlock with a
+// caller doing the same in the opposite order. Callers that need several
+// ranges should lock them in ascending order of start, or use
+// LockRangeContext with a deadline. Waiters are not queued, so a steady
+// stream of overlapping lockers may delay one indefinitely.
+//
+// Bounds that fail CheckRange, including inverted ones, yield its error.
+func (c *ConcurrentSkipList) LockRange(start, end interface{}) (*RangeLock, error) {
+	return c.LockRangeContext(context.Background(), start, end)
+}
+
+// LockRangeContext is LockRange that gives up and returns the error of ctx
+// once ctx is done
+func (c *ConcurrentSkipList) LockRangeContext(ctx context.Context, start, end interface{}) (*RangeLock, error) {
+	c.mu.RLock()
+	err := c.list.CheckRange(start, end)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &keyRange{start: start, end: end}
+	if err := c.ranges.acquire(ctx, r); err != nil {
+		return nil, err
+	}
+	return &RangeLock{c: c, r: r}, nil
+}
+
+// Unlock releases the range and wakes the writers waiting for it. Calling
+// Unlock more than once has no further effect.
+func (l *RangeLock) Unlock() {
+	if l.unlocked.CompareAndSwap(false, true) {
+		l.c.ranges.release(l.r)
+	}
+}
+
+// check returns an error unless the range is still held and key is a
+// valid key within it
+func (l *RangeLock) check(key interface{}) error {
+	if l.unlocked.Load() {
+		return ErrRangeUnlocked
+	}
+	if err := l.c.list.checkKey(key); err != nil {
+		return err
+	}
+	if !l.r.overlaps(&keyRange{start: key, end: key}) {
+		return fmt.Errorf("%w: %v", ErrKeyOutOfRange, key)
+	}
+	return nil
+}
+
+// Get returns the value stored under key and whether the key was found.
+// A key outside the range is reported as not found.
+func (l *RangeLock) Get(key interface{}) (interface{}, bool) {
+	if l.check(key) != nil {
+		return nil, false
+	}
+	return l.c.Get(key)
+}
+
+// Insert inserts a new key-value pair into the range. The returned error
+// matches ErrRangeUnlocked or ErrKeyOutOfRange under errors.Is, or is one
+// of those of SkipList.Insert.
+func (l *RangeLock) Insert(key, value interface{}) error {
+	if err := l.check(key); err != nil {
+		return err
+	}
+
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+	return l.c.list.Insert(key, value)
+}
+
+// InsertIfAbsent inserts a new key-value pair into the range unless the key
+// is already present and reports whether the pair was inserted
+func (l *RangeLock) InsertIfAbsent(key, value interface{}) (bool, error) {
+	if err := l.check(key); err != nil {
+		return false, err
+	}
+
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+	return l.c.list.InsertIfAbsent(key, value)
+}
+
+// Update replaces the value stored under key with fn applied to it
+func (l *RangeLock) Update(key interface{}, fn func(old interface{}) interface{}) error {
+	if err := l.check(key); err != nil {
+		return err
+	}
+
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+	return l.c.list.Update(key, fn)
+}
+
+// Delete deletes a key from the range
+func (l *RangeLock) Delete(key interface{}) error {
+	if err := l.check(key); err != nil {
+		return err
+	}
+
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+	return l.c.list.Delete(key)
+}
+
+// Entries returns every entry of the range in ascending key order. The
+// returned error matches ErrRangeUnlocked once the range is unlocked.
+func (l *RangeLock) Entries() ([]Entry, error) {
+	if l.unlocked.Load() {
+		return nil, ErrRangeUnlocked
+	}
+
+	l.c.mu.RLock()
+	defer l.c.mu.RUnlock()
+	return l.c.list.RangeQuery(l.r.start, l.r.end), nil
+}
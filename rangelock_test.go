@@ -0,0 +1,246 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blocked is how long an operation must stay pending to count as blocked
+const blocked = 50 * time.Millisecond
+
+// done runs fn in a goroutine and returns a channel closed once it returns
+func done(fn func()) chan struct{} {
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		fn()
+	}()
+	return ch
+}
+
+// waitDone fails t unless ch is closed within a second
+func waitDone(t *testing.T, ch chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatalf("%s did not finish", what)
+	}
+}
+
+// stillPending fails t if ch is closed within blocked
+func stillPending(t *testing.T, ch chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+		t.Fatalf("%s was not blocked", what)
+	case <-time.After(blocked):
+	}
+}
+
+func TestLockRangeDisjointRunInParallel(t *testing.T) {
+	c, _ := NewConcurrentSkipList(Int)
+
+	// Each writer holds its range until every writer has locked its own,
+	// which only happens if the ranges are held at the same time.
+	const writers = 4
+	var arrived sync.WaitGroup
+	arrived.Add(writers)
+	all := done(arrived.Wait)
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			lock, err := c.LockRange(w*10, w*10+9)
+			if err != nil {
+				t.Error(err)
+				arrived.Done()
+				return
+			}
+			defer lock.Unlock()
+
+			arrived.Done()
+			select {
+			case <-all:
+			case <-time.After(time.Second):
+				t.Errorf("Writer %d held its range alone", w)
+				return
+			}
+			for k := w * 10; k < w*10+10; k++ {
+				if err := lock.Insert(k, w); err != nil {
+					t.Error(err)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if c.Length() != writers*10 {
+		t.Fatalf("Length = %d, want %d", c.Length(), writers*10)
+	}
+}
+
+func TestLockRangeOverlappingSerialize(t *testing.T) {
+	c, _ := NewConcurrentSkipList(Int)
+
+	first, err := c.LockRange(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var second *RangeLock
+	ch := done(func() { second, _ = c.LockRange(10, 20) })
+	stillPending(t, ch, "LockRange of an overlapping range")
+	first.Unlock()
+	waitDone(t, ch, "LockRange after Unlock")
+	second.Unlock()
+
+	// Writers of overlapping ranges never hold them at the same time.
+	var inside, most atomic.Int32
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				lock, err := c.LockRange(w, w+10)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				n := inside.Add(1)
+				if n > most.Load() {
+					most.Store(n)
+				}
+				lock.Insert(w+5, i)
+				inside.Add(-1)
+				lock.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	if most.Load() != 1 {
+		t.Fatalf("%d overlapping ranges were held at once", most.Load())
+	}
+}
+
+func TestLockRangeBlocksWriters(t *testing.T) {
+	c, _ := NewConcurrentSkipList(Int)
+	for i := 0; i < 30; i += 5 {
+		c.Insert(i, i)
+	}
+
+	lock, err := c.LockRange(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes outside the range go ahead.
+	waitDone(t, done(func() { c.Insert(20, 0) }), "Insert outside the range")
+	waitDone(t, done(func() { c.PopMax() }), "PopMax outside the range")
+	if _, err := c.DeleteRange(15, 19); err != nil {
+		t.Fatal(err)
+	}
+
+	pending := map[string]chan struct{}{
+		"Insert":      done(func() { c.Insert(5, "x") }),
+		"Delete":      done(func() { c.Delete(10) }),
+		"Update":      done(func() { c.Update(0, func(interface{}) interface{} { return "x" }) }),
+		"PopMin":      done(func() { c.PopMin() }),
+		"DeleteRange": done(func() { c.DeleteRange(8, 12) }),
+		"Clear":       done(func() { c.Clear() }),
+	}
+	for name, ch := range pending {
+		stillPending(t, ch, name)
+	}
+
+	// Reads and the writes of the holder go ahead.
+	if _, ok := c.Get(5); !ok {
+		t.Fatal("Get of a locked key did not find it")
+	}
+	if err := lock.Insert(3, 3); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := lock.Entries(); err != nil || len(entries) != 4 {
+		t.Fatalf("Entries returned %d entries and %v, want 4 and nil", len(entries), err)
+	}
+
+	lock.Unlock()
+	for name, ch := range pending {
+		waitDone(t, ch, name)
+	}
+}
+
+func TestRangeLockChecksKeys(t *testing.T) {
+	c, _ := NewConcurrentSkipList(Int)
+	lock, err := c.LockRange(nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lock.Insert(-100, 0); err != nil {
+		t.Fatalf("Insert below an open lower bound: %v", err)
+	}
+	if err := lock.Insert(11, 0); !errors.Is(err, ErrKeyOutOfRange) {
+		t.Fatalf("Insert outside the range: got %v, want ErrKeyOutOfRange", err)
+	}
+	if err := lock.Insert("a", 0); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("Insert of a mismatched key: got %v, want ErrKeyTypeMismatch", err)
+	}
+
+	lock.Unlock()
+	lock.Unlock()
+
+	// Every method fails once the range is unlocked.
+	if err := lock.Insert(1, 0); !errors.Is(err, ErrRangeUnlocked) {
+		t.Errorf("Insert after Unlock: got %v, want ErrRangeUnlocked", err)
+	}
+	if _, err := lock.InsertIfAbsent(1, 0); !errors.Is(err, ErrRangeUnlocked) {
+		t.Errorf("InsertIfAbsent after Unlock: got %v, want ErrRangeUnlocked", err)
+	}
+	if err := lock.Update(-100, func(interface{}) interface{} { return 1 }); !errors.Is(err, ErrRangeUnlocked) {
+		t.Errorf("Update after Unlock: got %v, want ErrRangeUnlocked", err)
+	}
+	if err := lock.Delete(-100); !errors.Is(err, ErrRangeUnlocked) {
+		t.Errorf("Delete after Unlock: got %v, want ErrRangeUnlocked", err)
+	}
+	if entries, err := lock.Entries(); !errors.Is(err, ErrRangeUnlocked) || entries != nil {
+		t.Errorf("Entries after Unlock: got %v and %v, want ErrRangeUnlocked", entries, err)
+	}
+	if value, ok := lock.Get(-100); ok {
+		t.Errorf("Get after Unlock found %v", value)
+	}
+	if value, _ := c.Get(-100); value != 0 {
+		t.Errorf("Update after Unlock changed the value to %v", value)
+	}
+
+	if _, err := c.LockRange(5, 1); !errors.Is(err, ErrInvertedRange) {
+		t.Fatalf("Inverted bounds: got %v, want ErrInvertedRange", err)
+	}
+}
+
+func TestLockRangeContext(t *testing.T) {
+	c, _ := NewConcurrentSkipList(Int)
+	lock, _ := c.LockRange(1, 5)
+	defer lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), blocked)
+	defer cancel()
+	if _, err := c.LockRangeContext(ctx, 5, 9); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	other, err := c.LockRangeContext(context.Background(), 6, 9)
+	if err != nil {
+		t.Fatalf("Disjoint range: %v", err)
+	}
+	other.Unlock()
+}
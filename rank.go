@@ -0,0 +1,136 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "fmt"
+
+// locate returns the number of keys strictly less than key, accumulated
+// from the spans crossed while descending, and the level-0 successor of
+// the last node passed
+func (l *List[K, V]) locate(key K) (int, *node[K, V]) {
+	current := l.head
+	traversed := 0
+
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward[i] != nil && l.compare(current.forward[i].key, key) < 0 {
+			traversed += current.span[i]
+			current = current.forward[i]
+		}
+	}
+
+	return traversed, current.forward[0]
+}
+
+// countUpTo returns the number of keys less than or equal to key
+func (l *List[K, V]) countUpTo(key K) int {
+	current := l.head
+	traversed := 0
+
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward[i] != nil && l.compare(current.forward[i].key, key) <= 0 {
+			traversed += current.span[i]
+			current = current.forward[i]
+		}
+	}
+
+	return traversed
+}
+
+// Locate returns the 0-based rank key has or would have in the skip list,
+// whether it is present, and its value when it is. The rank is the number
+// of keys strictly less than key whether or not key is present.
+func (l *List[K, V]) Locate(key K) (int, bool, V) {
+	rank, n := l.locate(key)
+	if n != nil && l.compare(n.key, key) == 0 {
+		return rank, true, n.value
+	}
+
+	var zero V
+	return rank, false, zero
+}
+
+// Locate returns the 0-based rank key has or would have in the skip list,
+// whether it is present, and its value when it is. The rank is the number
+// of keys strictly less than key whether or not key is present. A nil or
+// mismatched key yields (0, false, nil).
+func (s *SkipList) Locate(key interface{}) (int, bool, interface{}) {
+	if s.checkKey(key) != nil {
+		return 0, false, nil
+	}
+	return s.list.Locate(key)
+}
+
+// byRank returns the node with the given 0-based rank, or nil if the rank
+// is out of range
+func (l *List[K, V]) byRank(rank int) *node[K, V] {
+	if rank < 0 || rank >= l.length {
+		return nil
+	}
+
+	current := l.head
+	traversed := 0
+
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward[i] != nil && traversed+current.span[i] <= rank+1 {
+			traversed += current.span[i]
+			current = current.forward[i]
+		}
+		if traversed == rank+1 {
+			return current
+		}
+	}
+
+	return nil
+}
+
+// Rank returns the 0-based rank of key and whether it is present
+func (l *List[K, V]) Rank(key K) (int, bool) {
+	rank, found, _ := l.Locate(key)
+	return rank, found
+}
+
+// GetByRank returns the key and value with the given 0-based rank and
+// whether the rank is in range
+func (l *List[K, V]) GetByRank(rank int) (K, V, bool) {
+	return l.entry(l.byRank(rank))
+}
+
+// Rank returns the 0-based rank of key in O(log n). The returned error
+// matches ErrNilKey, ErrKeyTypeMismatch or ErrKeyNotFound under errors.Is.
+func (s *SkipList) Rank(key interface{}) (int, error) {
+	if err := s.checkKey(key); err != nil {
+		return 0, err
+	}
+
+	rank, found := s.list.Rank(key)
+	if !found {
+		return 0, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+
+	return rank, nil
+}
+
+// GetByRank returns the key and value with the given 0-based rank in
+// O(log n). A rank outside [0, Length()) yields ErrRankOutOfRange.
+func (s *SkipList) GetByRank(rank int) (interface{}, interface{}, error) {
+	key, value, ok := s.list.GetByRank(rank)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %d", ErrRankOutOfRange, rank)
+	}
+
+	return key, value, nil
+}
+
+// Select returns the key and value with the given 0-based rank and whether
+// the rank is in range. It is the inverse of Rank: for a present key k,
+// Select(Rank(k)) yields k.
+func (l *List[K, V]) Select(rank int) (K, V, bool) {
+	return l.GetByRank(rank)
+}
+
+// Select returns the key and value with the given 0-based rank in
+// O(log n) and whether the rank is in [0, Length())
+func (s *SkipList) Select(rank int) (interface{}, interface{}, bool) {
+	return s.list.GetByRank(rank)
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestRankGetByRank(t *testing.T) {
+	s, _ := New(Int, WithRandSource(rand.NewSource(1)))
+	rng := rand.New(rand.NewSource(2))
+	var keys []int // Keys of s in ascending order
+	for step := 0; step < 3000; step++ {
+		key := rng.Intn(500)
+		at, found := slices.BinarySearch(keys, key)
+		switch {
+		case rng.Intn(3) == 0:
+			s.Delete(key)
+			if found {
+				keys = slices.Delete(keys, at, at+1)
+			}
+		case found:
+			// Overwrites leave the spans alone.
+			s.Insert(key, -key)
+		default:
+			s.Insert(key, key)
+			keys = slices.Insert(keys, at, key)
+		}
+
+		if step%100 != 99 {
+			continue
+		}
+		verify(t, s.list)
+		for rank, key := range keys {
+			if got, err := s.Rank(key); err != nil || got != rank {
+				t.Fatalf("Step %d: Rank(%d) = %d, %v, want %d", step, key, got, err, rank)
+			}
+			if got, _, err := s.GetByRank(rank); err != nil || got != key {
+				t.Fatalf("Step %d: GetByRank(%d) = %v, %v, want %d", step, rank, got, err, key)
+			}
+		}
+	}
+
+	// Deleting all but the smallest key shrinks the level to its tower.
+	for _, key := range keys[1:] {
+		s.Delete(key)
+	}
+	verify(t, s.list)
+	if height := len(s.list.head.forward[0].forward); s.list.level != height {
+		t.Fatalf("Level = %d with a single key of height %d left", s.list.level, height)
+	}
+	if rank, err := s.Rank(keys[0]); err != nil || rank != 0 {
+		t.Fatalf("Rank of the last key = %d, %v, want 0", rank, err)
+	}
+
+	if _, err := s.Rank(1000); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Rank of a missing key = %v, want ErrKeyNotFound", err)
+	}
+	for _, rank := range []int{-1, 1} {
+		if _, _, err := s.GetByRank(rank); !errors.Is(err, ErrRankOutOfRange) {
+			t.Errorf("GetByRank(%d) = %v, want ErrRankOutOfRange", rank, err)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	compares := 0
+	l, _ := NewListFunc[int, string](func(a, b int) int {
+		compares++
+		return a - b
+	}, WithRandSource(rand.NewSource(1)))
+	for _, key := range rand.New(rand.NewSource(2)).Perm(200) {
+		l.Insert(key*5, strconv.Itoa(key))
+	}
+	verify(t, l)
+
+	for key := -3; key <= 1003; key++ {
+		want := min(max((key+4)/5, 0), 200) // Multiples of 5 below key
+		compares = 0
+		rank, found, value := l.Locate(key)
+		located := compares
+		if rank != want || found != (key%5 == 0 && key >= 0 && key < 1000) {
+			t.Fatalf("Locate(%d) = %d, %t, want %d", key, rank, found, want)
+		}
+		if found && value != strconv.Itoa(key/5) {
+			t.Fatalf("Locate(%d) found %q", key, value)
+		} else if !found && value != "" {
+			t.Fatalf("Locate(%d) missed with value %q", key, value)
+		}
+
+		// One descent: no more comparisons than a lookup, plus the equality check.
+		compares = 0
+		l.Get(key)
+		if located > compares+1 {
+			t.Fatalf("Locate(%d) made %d comparisons, Get %d", key, located, compares)
+		}
+	}
+
+	s := NewSkipList(Int)
+	s.Insert(1, "a")
+	if rank, found, value := s.Locate("a"); rank != 0 || found || value != nil {
+		t.Fatalf("Locate of a mismatched key = %d, %t, %v", rank, found, value)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	s, _ := New(String, WithRandSource(rand.NewSource(1)))
+	for _, i := range rand.New(rand.NewSource(2)).Perm(300) {
+		s.Insert(strconv.Itoa(i), i)
+	}
+	verify(t, s.list)
+
+	// Select and Rank invert each other.
+	for i := 0; i < 300; i++ {
+		key := strconv.Itoa(i)
+		rank, err := s.Rank(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, value, ok := s.Select(rank)
+		if !ok || got != key || value != i {
+			t.Fatalf("Select(Rank(%q)) = %v, %v, %t", key, got, value, ok)
+		}
+	}
+	prev := ""
+	for rank := 0; rank < 300; rank++ {
+		key, _, ok := s.Select(rank)
+		if !ok || key.(string) <= prev {
+			t.Fatalf("Select(%d) = %v after %q", rank, key, prev)
+		}
+		prev = key.(string)
+	}
+
+	for _, rank := range []int{-1, 300, 1 << 40} {
+		if key, value, ok := s.Select(rank); ok || key != nil || value != nil {
+			t.Errorf("Select(%d) = %v, %v, %t, want nil, nil, false", rank, key, value, ok)
+		}
+	}
+	if _, _, ok := NewSkipList(Int).Select(0); ok {
+		t.Error("Select(0) on an empty list found a key")
+	}
+}
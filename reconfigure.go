@@ -0,0 +1,66 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Reconfigure applies opts to a live skip list. WithProbability and
+// WithRandSource affect the levels of nodes inserted afterwards, and
+// WithMaxComparisons, WithIterationChecks and WithStrict take effect with
+// the next operation. WithMaxLevel and WithInsertionOrder shape the nodes
+// already in the list, and WithAccessCounting and WithRecorder set up
+// state of a SkipList at construction, so changing them yields
+// ErrNotReconfigurable. Either every option is applied or, on error, none is.
+func (l *List[K, V]) Reconfigure(opts ...Option) error {
+	// Applying opts to zero options shows which settings they change.
+	var changes options
+	for _, opt := range opts {
+		if err := opt(&changes); err != nil {
+			return err
+		}
+	}
+
+	if changes.maxLevel != 0 && changes.maxLevel != l.opts.maxLevel {
+		return fmt.Errorf("%w: max level", ErrNotReconfigurable)
+	}
+	if changes.insertionOrder && (!l.opts.insertionOrder || changes.moveOnOverwrite != l.opts.moveOnOverwrite) {
+		return fmt.Errorf("%w: insertion order", ErrNotReconfigurable)
+	}
+	if changes.accessTopK != 0 && changes.accessTopK != l.opts.accessTopK {
+		return fmt.Errorf("%w: access counting", ErrNotReconfigurable)
+	}
+	if changes.recorder != nil {
+		return fmt.Errorf("%w: recorder", ErrNotReconfigurable)
+	}
+
+	o := l.opts
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return err
+		}
+	}
+
+	l.opts = o
+	if changes.randSource != nil {
+		l.rng = rand.New(changes.randSource)
+	}
+	return nil
+}
+
+// Reconfigure applies opts to a live skip list, with the same rules as
+// List.Reconfigure
+func (s *SkipList) Reconfigure(opts ...Option) error {
+	return s.list.Reconfigure(opts...)
+}
+
+// Reconfigure applies opts to a live skip list, with the same rules as
+// List.Reconfigure
+func (c *ConcurrentSkipList) Reconfigure(opts ...Option) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.list.Reconfigure(opts...)
+}
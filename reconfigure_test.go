@@ -0,0 +1,86 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestReconfigure(t *testing.T) {
+	s := NewSkipList(Int)
+	for i := 0; i < 100; i++ {
+		s.Insert(i, i)
+	}
+
+	if err := s.Reconfigure(WithStrict()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Insert(1, -1); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("Insert of a present key after WithStrict = %v, want ErrDuplicateKey", err)
+	}
+
+	if err := s.Reconfigure(WithMaxComparisons(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Insert(1000, 0); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Insert after WithMaxComparisons(1) = %v, want ErrBudgetExceeded", err)
+	}
+	if err := s.Reconfigure(WithMaxComparisons(0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Insert(1000, 0); err != nil {
+		t.Fatalf("Insert after lifting the budget = %v", err)
+	}
+	verify(t, s.list)
+
+	// Options fixed at construction are rejected unless they are unchanged.
+	var buf bytes.Buffer
+	for name, opt := range map[string]Option{
+		"WithMaxLevel":       WithMaxLevel(DefaultMaxLevel / 2),
+		"WithInsertionOrder": WithInsertionOrder(false),
+		"WithAccessCounting": WithAccessCounting(3),
+		"WithRecorder":       WithRecorder(&buf),
+	} {
+		if err := s.Reconfigure(opt); !errors.Is(err, ErrNotReconfigurable) {
+			t.Errorf("Reconfigure(%s) = %v, want ErrNotReconfigurable", name, err)
+		}
+	}
+	if err := s.Reconfigure(WithMaxLevel(DefaultMaxLevel)); err != nil {
+		t.Errorf("Reconfigure with the current max level = %v", err)
+	}
+	if err := s.Reconfigure(WithMaxComparisons(-1)); err == nil || errors.Is(err, ErrNotReconfigurable) {
+		t.Errorf("Reconfigure with an invalid budget = %v, want an option error", err)
+	}
+
+	// A rejected option keeps the others from being applied.
+	if err := s.Reconfigure(WithMaxComparisons(1), WithMaxLevel(2)); !errors.Is(err, ErrNotReconfigurable) {
+		t.Fatalf("Reconfigure with a fixed option = %v, want ErrNotReconfigurable", err)
+	}
+	if err := s.Insert(2000, 0); err != nil {
+		t.Fatalf("Insert after a rejected Reconfigure = %v, want the budget unchanged", err)
+	}
+}
+
+func TestReconfigureRandSource(t *testing.T) {
+	a, _ := NewList[int, int](WithRandSource(rand.NewSource(1)))
+	b, _ := NewList[int, int](WithRandSource(rand.NewSource(2)))
+	for _, l := range []*List[int, int]{a, b} {
+		if err := l.Reconfigure(WithRandSource(rand.NewSource(7))); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 200; i++ {
+			l.Insert(i, i)
+		}
+		verify(t, l)
+	}
+
+	for x, y := a.head.forward[0], b.head.forward[0]; x != nil; x, y = x.forward[0], y.forward[0] {
+		if len(x.forward) != len(y.forward) {
+			t.Fatalf("Key %d has %d and %d levels under the same source", x.key, len(x.forward), len(y.forward))
+		}
+	}
+}
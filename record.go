@@ -0,0 +1,477 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Operation codes of a recording
+const (
+	opInsert byte = iota + 1
+	opGet
+	opContains
+	opSearch
+	opDelete
+	opRemove
+	opUpdate
+	opUpsert
+	opInsertIfAbsent
+	opGetOrInsert
+	opReplace
+	opCompareAndSwap
+	opCompareAndDelete
+	opPopMin
+	opPopMax
+	opDeleteRange
+	opBulkInsert
+	opClear
+)
+
+// opNames names the operation codes in ReplayStats
+var opNames = map[byte]string{
+	opInsert:           "insert",
+	opGet:              "get",
+	opContains:         "contains",
+	opSearch:           "search",
+	opDelete:           "delete",
+	opRemove:           "remove",
+	opUpdate:           "update",
+	opUpsert:           "upsert",
+	opInsertIfAbsent:   "insertIfAbsent",
+	opGetOrInsert:      "getOrInsert",
+	opReplace:          "replace",
+	opCompareAndSwap:   "compareAndSwap",
+	opCompareAndDelete: "compareAndDelete",
+	opPopMin:           "popMin",
+	opPopMax:           "popMax",
+	opDeleteRange:      "deleteRange",
+	opBulkInsert:       "bulkInsert",
+	opClear:            "clear",
+}
+
+// recordMagic starts every recording
+var recordMagic = [4]byte{'S', 'K', 'P', 'R'}
+
+// recordVersion is the version of the recording format
+const recordVersion = 1
+
+// recorder appends the operations of a SkipList to a writer. Every method
+// is a no-op on a nil *recorder.
+type recorder struct {
+	mu   sync.Mutex    // Guards the fields below; reads may run concurrently
+	w    *bufio.Writer // Buffered destination of the recording
+	last time.Time     // Time of the previous record
+	buf  []byte        // Scratch space for one record
+	err  error         // First write error, after which nothing is recorded
+}
+
+// newRecorder returns a recorder writing to w, starting with the header
+func newRecorder(w io.Writer) *recorder {
+	r := &recorder{w: bufio.NewWriter(w)}
+	r.buf = append(r.buf, recordMagic[:]...)
+	r.buf = append(r.buf, recordVersion)
+	_, r.err = r.w.Write(r.buf)
+	return r
+}
+
+// write appends one record holding the op code, the nanoseconds since the
+// previous record and the payload appended by fill
+func (r *recorder) write(op byte, fill func(buf []byte) ([]byte, error)) {
+	if r == nil {
+		return
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+
+	var delta time.Duration
+	if !r.last.IsZero() {
+		delta = now.Sub(r.last)
+	}
+	r.last = now
+
+	buf := append(r.buf[:0], op)
+	buf = binary.AppendUvarint(buf, uint64(max(delta, 0)))
+	if buf, r.err = fill(buf); r.err != nil {
+		return
+	}
+	r.buf = buf
+
+	_, r.err = r.w.Write(buf)
+}
+
+// appendSize appends the size of value when it is a []byte or string, and 0 otherwise
+func appendSize(buf []byte, value interface{}) []byte {
+	size := 0
+	switch v := value.(type) {
+	case []byte:
+		size = len(v)
+	case string:
+		size = len(v)
+	}
+	return binary.AppendUvarint(buf, uint64(size))
+}
+
+// appendEntry appends the natively encoded key and the size of value
+func appendEntry(buf []byte, key, value interface{}) ([]byte, error) {
+	buf, err := encodeNative(buf, key)
+	if err != nil {
+		return nil, err
+	}
+	return appendSize(buf, value), nil
+}
+
+// record appends an operation on key whose payload is the key and the size of value
+func (r *recorder) record(op byte, key, value interface{}) {
+	r.write(op, func(buf []byte) ([]byte, error) {
+		return appendEntry(buf, key, value)
+	})
+}
+
+// recordSwap appends a CompareAndSwap, whose payload is the key, the size
+// of the new value and the size of the expected old one
+func (r *recorder) recordSwap(key, old, new interface{}) {
+	r.write(opCompareAndSwap, func(buf []byte) ([]byte, error) {
+		buf, err := appendEntry(buf, key, new)
+		if err != nil {
+			return nil, err
+		}
+		return appendSize(buf, old), nil
+	})
+}
+
+// recordOp appends an operation without a payload
+func (r *recorder) recordOp(op byte) {
+	r.write(op, func(buf []byte) ([]byte, error) {
+		return buf, nil
+	})
+}
+
+// recordRange appends a DeleteRange, whose payload is both bounds, nil for an open side
+func (r *recorder) recordRange(start, end interface{}) {
+	r.write(opDeleteRange, func(buf []byte) ([]byte, error) {
+		buf, err := encodeNative(buf, start)
+		if err != nil {
+			return nil, err
+		}
+		return encodeNative(buf, end)
+	})
+}
+
+// recordBatch appends a BulkInsert, whose payload is the number of keys
+// followed by every key and value size
+func (r *recorder) recordBatch(keys, values []interface{}) {
+	r.write(opBulkInsert, func(buf []byte) ([]byte, error) {
+		buf = binary.AppendUvarint(buf, uint64(len(keys)))
+		for i, key := range keys {
+			var value interface{}
+			if i < len(values) {
+				value = values[i]
+			}
+			var err error
+			if buf, err = appendEntry(buf, key, value); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	})
+}
+
+// flush writes out buffered records and returns the first error met
+func (r *recorder) flush() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.err = r.w.Flush()
+	return r.err
+}
+
+// WithRecorder makes a SkipList log its point operations to w, for Replay:
+// Insert, Upsert, InsertIfAbsent, GetOrInsert, Replace, Update, InsertAt,
+// CompareAndSwap, CompareAndDelete, Get, Contains, Search, SearchForInsert,
+// Delete and Remove with a valid key, as well as PopMin, PopMax, DeleteRange,
+// BulkInsert and Clear. Range scans, iterators, neighbor and rank queries,
+// MultiGet, ClearIncremental, Merge and Split are not recorded. Each record
+// holds the op code, the time since the previous one, the keys and the size
+// of each []byte or string value; values themselves are not recorded.
+// Records are buffered, so FlushRecording must be called before w is read
+// or closed. Recording stops at the first write error, which FlushRecording
+// returns. Only operations that succeed are recorded: calls that return an
+// error, lookups and removals that miss, an InsertIfAbsent of a present
+// key, swaps and deletes whose expected value does not match and pops of
+// an empty list are left out, so a replay
+// against an empty list rebuilds the recorded contents. Clones and lists
+// created by Split do not record, and Namespaces rejects the option.
+func WithRecorder(w io.Writer) Option {
+	return func(o *options) error {
+		if w == nil {
+			return fmt.Errorf("Recorder cannot be nil")
+		}
+		o.recorder = w
+		return nil
+	}
+}
+
+// FlushRecording writes out the operations buffered by the recorder set
+// with WithRecorder and returns the first error met while recording
+func (s *SkipList) FlushRecording() error {
+	return s.rec.flush()
+}
+
+// FlushRecording writes out the operations buffered by the recorder set
+// with WithRecorder
+func (c *ConcurrentSkipList) FlushRecording() error {
+	return c.list.FlushRecording()
+}
+
+// OpStats summarizes the latencies of one kind of operation in a replay
+type OpStats struct {
+	Count  int           // Number of operations replayed
+	Errors int           // Operations that returned an error, including ErrKeyNotFound
+	P50    time.Duration // Median latency
+	P90    time.Duration // 90th percentile latency
+	P99    time.Duration // 99th percentile latency
+	Max    time.Duration // Largest latency
+}
+
+// ReplayStats is the outcome of Replay
+type ReplayStats struct {
+	Ops     map[string]OpStats // Latencies by operation, named after the method in lower camel case, e.g. get or deleteRange
+	Elapsed time.Duration      // Wall time of the whole replay
+}
+
+// replayOp is one decoded record of a recording
+type replayOp struct {
+	op    byte          // Operation code
+	delta time.Duration // Time since the previous record
+	keys  []interface{} // Keys, or the bounds of a DeleteRange
+	sizes []int         // Recorded value size of every key, then the old value size of a CompareAndSwap
+}
+
+// readReplayOp reads the record starting with op
+func readReplayOp(br binaryReader, op byte) (replayOp, error) {
+	rec := replayOp{op: op}
+	delta, err := br.readUvarint()
+	if err != nil {
+		return rec, err
+	}
+	rec.delta = time.Duration(delta)
+
+	readSize := func() error {
+		size, err := br.readUvarint()
+		if err != nil {
+			return err
+		}
+		if size > uint64(MaxDecodeBlobSize) {
+			return fmt.Errorf("%w: value size %d exceeds MaxDecodeBlobSize", ErrInvalidStream, size)
+		}
+		rec.sizes = append(rec.sizes, int(size))
+		return nil
+	}
+	readEntry := func() error {
+		key, err := br.readNative()
+		if err != nil {
+			return err
+		}
+		rec.keys = append(rec.keys, key)
+		return readSize()
+	}
+
+	switch op {
+	case opPopMin, opPopMax, opClear:
+		return rec, nil
+	case opDeleteRange:
+		for range 2 {
+			bound, err := br.readNative()
+			if err != nil {
+				return rec, err
+			}
+			rec.keys = append(rec.keys, bound)
+		}
+		return rec, nil
+	case opBulkInsert:
+		count, err := br.readUvarint()
+		if err != nil {
+			return rec, err
+		}
+		for range count {
+			if err := readEntry(); err != nil {
+				return rec, err
+			}
+		}
+		return rec, nil
+	case opCompareAndSwap:
+		if err := readEntry(); err != nil {
+			return rec, err
+		}
+		return rec, readSize()
+	default:
+		return rec, readEntry()
+	}
+}
+
+// values returns a zeroed []byte of the recorded size for every key. They
+// share one buffer, so a corrupt batch claiming many large values costs no
+// more memory than its largest one.
+func (rec replayOp) values() []interface{} {
+	zero := make([]byte, slices.Max(append(rec.sizes, 0)))
+	values := make([]interface{}, len(rec.sizes))
+	for i, size := range rec.sizes {
+		values[i] = zero[:size:size]
+	}
+	return values
+}
+
+// run applies the operation of rec to target with the given values and
+// returns its error, if any
+func (rec replayOp) run(target *SkipList, values []interface{}) error {
+	var key, value interface{}
+	if len(rec.keys) > 0 && rec.op != opBulkInsert {
+		key = rec.keys[0]
+	}
+	if len(values) > 0 {
+		value = values[0]
+	}
+
+	var err error
+	switch rec.op {
+	case opInsert:
+		err = target.Insert(key, value)
+	case opGet:
+		target.Get(key)
+	case opContains:
+		target.Contains(key)
+	case opSearch:
+		_, err = target.Search(key)
+	case opDelete:
+		err = target.Delete(key)
+	case opRemove:
+		target.Remove(key)
+	case opUpdate:
+		err = target.Update(key, func(interface{}) interface{} { return value })
+	case opUpsert:
+		_, err = target.Upsert(key, value)
+	case opInsertIfAbsent:
+		_, err = target.InsertIfAbsent(key, value)
+	case opGetOrInsert:
+		_, _, err = target.GetOrInsert(key, value)
+	case opReplace:
+		err = target.Replace(key, value)
+	case opCompareAndSwap:
+		_, err = target.CompareAndSwap(key, values[1], value)
+	case opCompareAndDelete:
+		_, err = target.CompareAndDelete(key, value)
+	case opPopMin:
+		target.PopMin()
+	case opPopMax:
+		target.PopMax()
+	case opDeleteRange:
+		_, err = target.DeleteRange(rec.keys[0], rec.keys[1])
+	case opBulkInsert:
+		err = target.BulkInsert(rec.keys, values)
+	case opClear:
+		target.Clear()
+	}
+	return err
+}
+
+// Replay reads a recording written through WithRecorder and runs its
+// operations against target, reporting latency percentiles per operation.
+// Values are zeroed []byte of the recorded size, so a CompareAndSwap or
+// CompareAndDelete succeeds where the stored value has the size of the
+// expected one.
+// With speed 1 the operations are spaced as they were recorded, with speed
+// 2 twice as fast, and with speed <= 0 they run back to back. A recording
+// with the wrong magic number or version, or a truncated or corrupt one,
+// yields an error matching ErrInvalidStream, with the stats of the
+// operations run so far.
+func Replay(r io.Reader, target *SkipList, speed float64) (ReplayStats, error) {
+	br := binaryReader{r: bufio.NewReader(r)}
+	latencies := make(map[byte][]time.Duration)
+	errs := make(map[byte]int)
+
+	start := time.Now()
+	stats := func() ReplayStats {
+		st := ReplayStats{Ops: make(map[string]OpStats), Elapsed: time.Since(start)}
+		for op, lat := range latencies {
+			slices.Sort(lat)
+			pct := func(p float64) time.Duration {
+				return lat[int(math.Ceil(p*float64(len(lat))))-1]
+			}
+			st.Ops[opNames[op]] = OpStats{
+				Count:  len(lat),
+				Errors: errs[op],
+				P50:    pct(0.5),
+				P90:    pct(0.9),
+				P99:    pct(0.99),
+				Max:    lat[len(lat)-1],
+			}
+		}
+		return st
+	}
+
+	var header [5]byte
+	if _, err := io.ReadFull(br.r, header[:]); err != nil {
+		return stats(), truncated(err)
+	}
+	if [4]byte(header[:4]) != recordMagic {
+		return stats(), fmt.Errorf("%w: bad magic number %q", ErrInvalidStream, header[:4])
+	}
+	if header[4] != recordVersion {
+		return stats(), fmt.Errorf("%w: unsupported version %d", ErrInvalidStream, header[4])
+	}
+
+	var due time.Duration
+	for i := 0; ; i++ {
+		op, err := br.r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return stats(), nil
+		}
+		if err != nil {
+			return stats(), err
+		}
+		if opNames[op] == "" {
+			return stats(), fmt.Errorf("%w: record %d: unknown op code %d", ErrInvalidStream, i, op)
+		}
+
+		rec, err := readReplayOp(br, op)
+		if err != nil {
+			return stats(), fmt.Errorf("Record %d: %w", i, err)
+		}
+
+		if speed > 0 {
+			due += time.Duration(float64(rec.delta) / speed)
+			if wait := due - time.Since(start); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+
+		values := rec.values()
+		begin := time.Now()
+		err = rec.run(target, values)
+		latencies[op] = append(latencies[op], time.Since(begin))
+		if err != nil {
+			errs[op]++
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// valueSizes returns the length of the []byte value of every key
+func valueSizes(t *testing.T, s *SkipList) map[interface{}]int {
+	t.Helper()
+	sizes := make(map[interface{}]int)
+	for key, value := range s.All() {
+		b, ok := value.([]byte)
+		if !ok {
+			t.Fatalf("Value of %v is %T, want []byte", key, value)
+		}
+		sizes[key] = len(b)
+	}
+	return sizes
+}
+
+func TestRecordReplay(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := New(Int, WithRecorder(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := func(n int) []byte { return make([]byte, n) }
+	for i := 0; i < 20; i++ {
+		s.Insert(i, value(i))
+	}
+	s.Get(3)
+	s.Contains(4)
+	s.Search(100)
+	s.Delete(5)
+	s.Remove(6)
+	s.Update(7, func(interface{}) interface{} { return value(70) })
+	s.Upsert(30, value(3))
+	s.InsertIfAbsent(31, value(4))
+	s.InsertIfAbsent(30, value(5))
+	s.GetOrInsert(32, value(6))
+	s.Replace(8, value(80))
+	s.CompareAndSwap(9, value(9), value(90))
+	s.CompareAndSwap(10, value(1), value(100))
+	s.CompareAndDelete(11, value(11))
+	s.PopMin()
+	s.PopMax()
+	s.DeleteRange(14, 16)
+	s.DeleteRange(nil, 1)
+	s.BulkInsert([]interface{}{40, 41, 42}, []interface{}{value(1), value(2), value(3)})
+	_, _, pos := s.SearchForInsert(50)
+	s.InsertAt(pos, 50, value(5))
+	if err := s.FlushRecording(); err != nil {
+		t.Fatal(err)
+	}
+
+	target := NewSkipList(Int)
+	stats, err := Replay(bytes.NewReader(buf.Bytes()), target, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, got := valueSizes(t, s), valueSizes(t, target)
+	if len(got) != len(want) {
+		t.Fatalf("Replay left %d keys, want %d", len(got), len(want))
+	}
+	for key, size := range want {
+		if got[key] != size {
+			t.Fatalf("Value of %v has %d bytes after replay, want %d", key, got[key], size)
+		}
+	}
+
+	for name, count := range map[string]int{"insert": 21, "compareAndSwap": 1, "deleteRange": 2, "bulkInsert": 1, "get": 1, "search": 0} {
+		if stats.Ops[name].Count != count {
+			t.Fatalf("Replayed %d %s operations, want %d", stats.Ops[name].Count, name, count)
+		}
+	}
+
+	// Clear is replayed too.
+	s.Clear()
+	s.FlushRecording()
+	if _, err := Replay(bytes.NewReader(buf.Bytes()), target, 0); err != nil {
+		t.Fatal(err)
+	}
+	if target.Length() != 0 {
+		t.Fatalf("Replay of Clear left %d keys", target.Length())
+	}
+}
+
+func TestRecordSkipsFailures(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := New(Int, WithRecorder(&buf), WithStrict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := s.PopMin(); ok {
+		t.Fatal("PopMin of an empty list succeeded")
+	}
+	s.Insert(1, []byte("a"))
+
+	// Each of these misses or fails and must not reach the recording.
+	s.Insert(1, []byte("b"))
+	s.Insert("a", []byte("b"))
+	s.Get(2)
+	s.Contains(2)
+	s.Search(2)
+	s.Delete(2)
+	s.Remove(2)
+	s.Replace(2, []byte("b"))
+	s.Update(2, func(interface{}) interface{} { return []byte("bb") })
+	s.InsertIfAbsent(1, []byte("b"))
+	s.CompareAndSwap(2, []byte("a"), []byte("b"))
+	s.CompareAndSwap(1, []byte("x"), []byte("b"))
+	s.CompareAndDelete(2, []byte("a"))
+	s.CompareAndDelete(1, []byte("x"))
+	s.BulkInsert([]interface{}{3, 2}, []interface{}{[]byte("c"), []byte("b")})
+	_, _, pos := s.SearchForInsert(1)
+	if _, err := s.InsertAt(pos, 1, []byte("b")); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("InsertAt of a present key in strict mode = %v, want ErrDuplicateKey", err)
+	}
+	if err := s.FlushRecording(); err != nil {
+		t.Fatal(err)
+	}
+
+	target := NewSkipList(Int)
+	stats, err := Replay(bytes.NewReader(buf.Bytes()), target, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the first Insert and the lookup of SearchForInsert succeeded.
+	if len(stats.Ops) != 2 || stats.Ops["insert"].Count != 1 || stats.Ops["get"].Count != 1 {
+		t.Fatalf("Replayed %v, want an insert and a get", stats.Ops)
+	}
+	if sizes := valueSizes(t, target); len(sizes) != 1 || sizes[1] != 1 {
+		t.Fatalf("Replay left %v, want 1 with a 1-byte value", sizes)
+	}
+}
+
+func TestReplayRejectsCorruptRecordings(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := New(Int, WithRecorder(&buf))
+	s.Insert(1, []byte("a"))
+	s.FlushRecording()
+	good := buf.Bytes()
+
+	for name, data := range map[string][]byte{
+		"magic":     append([]byte("XXXX"), good[4:]...),
+		"version":   append(append([]byte(nil), good[:4]...), append([]byte{99}, good[5:]...)...),
+		"op":        append(append([]byte(nil), good...), 200),
+		"truncated": good[:len(good)-1],
+	} {
+		if _, err := Replay(bytes.NewReader(data), NewSkipList(Int), 0); !errors.Is(err, ErrInvalidStream) {
+			t.Errorf("%s: got %v, want ErrInvalidStream", name, err)
+		}
+	}
+}
+
+func TestNamespacesRejectRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := NewNamespaces(Int, WithRecorder(&buf)); err == nil {
+		t.Fatal("NewNamespaces accepted WithRecorder")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Rejected recorder was written %d bytes", buf.Len())
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "iter"
+
+// All returns an iterator over the key-value pairs of the skip list in
+// ascending key order, for use with range. It walks level 0 directly and
+// reads the next node only after the loop body has run, so the body may
+// insert or delete keys: keys inserted ahead of the current one are seen,
+// and after the current key is deleted the walk goes on from the node that
+// followed it at the time, even if that one has been deleted since. Under
+// WithIterationChecks each step is checked as by an iterator.
+func (l *List[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := l.head.forward[0]; n != nil; n = l.next(n) {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the skip list in ascending order
+func (l *List[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for n := l.head.forward[0]; n != nil; n = l.next(n) {
+			if !yield(n.key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the skip list in ascending key order
+func (l *List[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := l.head.forward[0]; n != nil; n = l.next(n) {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the key-value pairs of the skip list in
+// ascending key order, for use with range. Keys inserted or deleted by the
+// loop body are handled as by List.All.
+func (s *SkipList) All() iter.Seq2[interface{}, interface{}] {
+	return s.list.All()
+}
+
+// Keys returns an iterator over the keys of the skip list in ascending order
+func (s *SkipList) Keys() iter.Seq[interface{}] {
+	return s.list.Keys()
+}
+
+// Values returns an iterator over the values of the skip list in ascending key order
+func (s *SkipList) Values() iter.Seq[interface{}] {
+	return s.list.Values()
+}
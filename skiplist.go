@@ -1,55 +1,58 @@
 // Copyright 2023 Qi Shen. All rights reserved.
 // Licensed under the MIT license that can be found in the LICENSE file.
-// Package myskiplist implements a skip list data structure.
+
+// Package SkipList implements a skip list data structure.
 //
-// Skip lists are probabilistic data structures that provide.
-// the same asymptotic complexity as balanced trees and are.
-// simpler and faster in practice.
+// Skip lists are probabilistic data structures that provide the same
+// expected complexity as balanced trees and are simpler and faster in
+// practice. See https://en.wikipedia.org/wiki/Skip_list for more
+// information.
 //
-// See https://en.wikipedia.org/wiki/Skip_list for more information.
-// This package provides a generic implementation of skip lists.
-// The keys in the skip list can be of any type that implements.
-// the sort.Interface interface.
+// SkipList keeps interface{} keys of a single ordered type, given to the
+// constructor as a reflect.Type such as Int or String, and List offers the
+// same structure with type parameters and an optional comparison function.
 // The following example shows how to use the package:
-
-// package main
 //
-// import (
-// 	"fmt"
-// 	"github.com/qishenonly/SkipList"
-// )
+//	package main
 //
-// func main() {
-// 	// Create a new skip list
-// 	list := skipist.New(skiplist.Int)
+//	import (
+//		"fmt"
 //
-// 	// Insert elements into the skip list
-// 	list.Insert(3, "three")
-// 	list.Insert(1, "one")
-// 	list.Insert(2, "two")
+//		skiplist "github.com/qishenonly/SkipList"
+//	)
 //
-// 	// Get element from the skip list
-// 	value, ok := list.Get(2)
-// 	if ok {
-// 		fmt.Println(value)
-// 	}
+//	func main() {
+//		// Create a new skip list with int keys
+//		list, err := skiplist.New(skiplist.Int)
+//		if err != nil {
+//			panic(err)
+//		}
 //
-// 	// Remove element from the skip list
-// 	list.Remove(2)
+//		// Insert elements into the skip list
+//		list.Insert(3, "three")
+//		list.Insert(1, "one")
+//		list.Insert(2, "two")
 //
-// 	// Get element from the skip list
-// 	value, ok = list.Get(2)
-// 	if ok {
-// 		fmt.Println(value)
-// 	}
-// }
-
-
+//		// Get element from the skip list
+//		value, ok := list.Get(2)
+//		if ok {
+//			fmt.Println(value)
+//		}
+//
+//		// Remove element from the skip list
+//		list.Remove(2)
+//
+//		// Get element from the skip list
+//		value, ok = list.Get(2)
+//		if ok {
+//			fmt.Println(value)
+//		}
+//	}
 package SkipList
 
 import (
-	"errors"
-	"math/rand"
+	"cmp"
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
@@ -58,48 +61,130 @@ import (
 // Default maximum level for the skip list
 var DefaultMaxLevel = 48
 
-// Node represents a node in the skip list
-type node struct {
-	key     interface{} // Key of the node
-	value   interface{} // Value of the node
-	forward []*node     // Forward pointers of the node
-}
+// Key types supported by the ordering of SkipList, for use with NewSkipList
+// and New. Float keys are ordered as by cmp.Compare: NaN sorts before every
+// other value and equals itself, so a NaN key can be stored once. Keys of
+// the other integer widths and of uintptr are ordered too, as are types
+// defined over any of them, such as type ID int, which are ordered like
+// their underlying type.
+var (
+	Int     = reflect.TypeOf(int(0))
+	Int64   = reflect.TypeOf(int64(0))
+	Uint64  = reflect.TypeOf(uint64(0))
+	String  = reflect.TypeOf("")
+	Float64 = reflect.TypeOf(float64(0))
+	Float32 = reflect.TypeOf(float32(0))
+)
+
+// anyList is the list type backing a SkipList
+type anyList = List[interface{}, interface{}]
 
 // SkipList represents the skip list structure
 type SkipList struct {
-	head    *node         // Head node of the skip list
-	level   int           // Current level of the skip list
-	length  int           // Length of the skip list (number of nodes)
-	keyType reflect.Type  // Type of the keys in the skip list
+	list    *anyList       // Underlying typed skip list
+	keyType reflect.Type   // Type of the keys in the skip list
+	access  *accessCounter // Read counts, nil unless WithAccessCounting is set
+	rec     *recorder      // Operation log, nil unless WithRecorder is set
 }
 
 // SkipListIterator represents the iterator for the skip list
 type SkipListIterator struct {
-	list   *SkipList  // The skip list associated with the iterator
-	node   *node      // Current node being iterated
-	isHead bool       // Flag to indicate if the current node is the head node
+	list *SkipList                               // The skip list associated with the iterator
+	it   *ListIterator[interface{}, interface{}] // Underlying typed iterator
+	err  error                                   // Why the iterator is empty or exhausted, reported by Err
 }
 
-// NewSkipList creates a new skip list with the specified key type
+// NewSkipList creates a new skip list with the specified key type.
+// A nil key type accepts keys of any ordered type, but a skip list holding
+// keys only accepts more keys of the same type. NewSkipList panics if the
+// key type is not ordered; New returns ErrUnorderedKeyType instead.
 func NewSkipList(keyType reflect.Type) *SkipList {
-	head := &node{
-		forward: make([]*node, 1),
+	s, err := New(keyType)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// New creates a new skip list with the specified key type and options.
+// Key types that SkipList cannot order, such as slices and structs, are
+// rejected with ErrUnorderedKeyType.
+func New(keyType reflect.Type, opts ...Option) (*SkipList, error) {
+	if keyType != nil && !orderedType(keyType) {
+		return nil, fmt.Errorf("%w: %v", ErrUnorderedKeyType, keyType)
 	}
-	return &SkipList{
-		head:    head,
-		level:   1,
-		length:  0,
+	s := &SkipList{
 		keyType: keyType,
 	}
+	if err := s.init(opts); err != nil {
+		return nil, err
+	}
+
+	return s, nil
 }
 
-// randomLevel generates a random level for the new node in the skip list
-func (s *SkipList) randomLevel() int {
-	level := 1
-	for rand.Float64() < 0.5 && level < 32 {
-		level++
+// init creates the underlying list of s with the given options
+func (s *SkipList) init(opts []Option) error {
+	list, err := NewListFunc[interface{}, interface{}](s.compare, opts...)
+	if err != nil {
+		return err
+	}
+	s.list = list
+	if list.opts.accessTopK > 0 {
+		s.access = newAccessCounter(list.opts.accessTopK)
 	}
-	return level
+	if list.opts.recorder != nil {
+		s.rec = newRecorder(list.opts.recorder)
+	}
+
+	return nil
+}
+
+// checkKey validates that key is non-nil and matches the key type of the
+// skip list. A skip list without key type accepts a key of any ordered type
+// while it is empty, and keys of the type it holds otherwise.
+func (s *SkipList) checkKey(key interface{}) error {
+	if key == nil {
+		return ErrNilKey
+	}
+
+	keyType := s.heldKeyType()
+	if keyType == nil {
+		if !orderedType(reflect.TypeOf(key)) {
+			return fmt.Errorf("%w: %T", ErrUnorderedKeyType, key)
+		}
+	} else if reflect.TypeOf(key) != keyType {
+		return fmt.Errorf("%w: expected %v, got %T", ErrKeyTypeMismatch, keyType, key)
+	}
+
+	return nil
+}
+
+// checkKeys validates keys as checkKey does, and also requires them to
+// share one type, as they would have to once inserted
+func (s *SkipList) checkKeys(keys []interface{}) error {
+	for i, key := range keys {
+		if err := s.checkKey(key); err != nil {
+			return err
+		}
+		if i > 0 && reflect.TypeOf(key) != reflect.TypeOf(keys[0]) {
+			return fmt.Errorf("%w: expected %T, got %T", ErrKeyTypeMismatch, keys[0], key)
+		}
+	}
+	return nil
+}
+
+// heldKeyType returns the type keys of the skip list must have: its key
+// type, or for a skip list made without one the type of the keys it holds.
+// It returns nil for an empty skip list without key type.
+func (s *SkipList) heldKeyType() reflect.Type {
+	if s.keyType != nil || s.list == nil {
+		return s.keyType
+	}
+	if first := s.list.head.forward[0]; first != nil {
+		return reflect.TypeOf(first.key)
+	}
+	return nil
 }
 
 // compareInt compares two integers and returns the comparison result
@@ -140,206 +225,487 @@ func compareString(a, b interface{}) int {
 
 // compare compares two keys and returns the comparison result
 func (s *SkipList) compare(a, b interface{}) int {
+	return compareOrdered(a, b)
+}
+
+// compareOrdered compares two values of the same ordered type: a built-in
+// one, or a type defined over one. Values of different or other types
+// compare as equal.
+func compareOrdered(a, b interface{}) int {
 	switch a := a.(type) {
 	case int:
-		b, ok := b.(int)
-		if !ok {
-			return 0
-		}
-		if a < b {
-			return -1
-		} else if a > b {
-			return 1
-		} else {
-			return 0
-		}
+		return compareAs(a, b)
+	case int8:
+		return compareAs(a, b)
+	case int16:
+		return compareAs(a, b)
+	case int32:
+		return compareAs(a, b)
+	case int64:
+		return compareAs(a, b)
+	case uint:
+		return compareAs(a, b)
+	case uint8:
+		return compareAs(a, b)
+	case uint16:
+		return compareAs(a, b)
+	case uint32:
+		return compareAs(a, b)
+	case uint64:
+		return compareAs(a, b)
+	case uintptr:
+		return compareAs(a, b)
 	case string:
-		b, ok := b.(string)
-		if !ok {
-			return 0
-		}
-		return strings.Compare(a, b)
+		return compareAs(a, b)
+	case float64:
+		return compareAs(a, b)
+	case float32:
+		return compareAs(a, b)
+	default:
+		return compareUnderlying(a, b)
+	}
+}
+
+// compareUnderlying compares two values of the same type defined over a
+// built-in ordered type as values of that type. Values of different or
+// other types compare as equal.
+func compareUnderlying(a, b interface{}) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return 0
+	}
+
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	case reflect.String:
+		return strings.Compare(va.String(), vb.String())
 	default:
 		return 0
 	}
 }
 
-// Insert inserts a new key-value pair into the skip list
-func (s *SkipList) Insert(key, value interface{}) error {
-	if key == nil {
-		return errors.New("Key cannot be nil")
+// orderedType reports whether compareOrdered orders values of type t
+func orderedType(t reflect.Type) bool {
+	if t == nil {
+		return false
 	}
 
-	update := make([]*node, s.level)
-	current := s.head
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	default:
+		return false
+	}
+}
+
+// compareAs compares a with b if b has the same type as a, and returns 0 otherwise
+func compareAs[T cmp.Ordered](a T, b interface{}) int {
+	bt, ok := b.(T)
+	if !ok {
+		return 0
+	}
+	return cmp.Compare(a, bt)
+}
+
+// Insert inserts a new key-value pair into the skip list.
+// Keys cannot be nil and must match the key type of the skip list,
+// but nil values are stored like any other value. An existing key has its
+// value replaced, or in strict mode yields ErrDuplicateKey.
+func (s *SkipList) Insert(key, value interface{}) error {
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
 
-	for i := s.level - 1; i >= 0; i-- {
-		for current.forward[i] != nil && s.compare(current.forward[i].key, key) < 0 {
-			current = current.forward[i]
+	if s.list.opts.strict {
+		inserted := true
+		if err := s.withBudget(func() {
+			inserted = s.list.InsertIfAbsent(key, value)
+		}); err != nil {
+			return err
 		}
-		update[i] = current
+		if !inserted {
+			return fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+		}
+		s.rec.record(opInsert, key, value)
+		return nil
 	}
 
-	current = current.forward[0]
+	if err := s.withBudget(func() {
+		s.list.Insert(key, value)
+	}); err != nil {
+		return err
+	}
+	s.rec.record(opInsert, key, value)
+	return nil
+}
 
-	if current != nil && s.compare(current.key, key) == 0 {
-		current.value = value
-	} else {
-		level := s.randomLevel()
+// GetOrInsert returns the existing value for key if present. Otherwise it
+// inserts value and returns it. The loaded result is true if the value was
+// loaded, false if stored, as with sync.Map.LoadOrStore. Both happen in a
+// single descent. The returned error matches ErrNilKey, ErrKeyTypeMismatch
+// or ErrBudgetExceeded under errors.Is, in which case nothing is stored.
+func (s *SkipList) GetOrInsert(key, value interface{}) (interface{}, bool, error) {
+	if err := s.checkKey(key); err != nil {
+		return nil, false, err
+	}
 
-		if level > s.level {
-			for i := s.level; i < level; i++ {
-				update[i] = s.head
-			}
-			s.level = level
-		}
+	var actual interface{}
+	var loaded bool
+	if err := s.withBudget(func() {
+		actual, loaded = s.list.GetOrInsert(key, value)
+	}); err != nil {
+		return nil, false, err
+	}
+	s.rec.record(opGetOrInsert, key, value)
 
-		newNode := &node{
-			key:     key,
-			value:   value,
-			forward: make([]*node, level),
-		}
+	return actual, loaded, nil
+}
 
-		for i := 0; i < level; i++ {
-			newNode.forward[i] = update[i].forward[i]
-			update[i].forward[i] = newNode
-		}
+// InsertIfAbsent inserts a new key-value pair unless the key is already
+// present, in which case the stored value is left untouched. It reports
+// whether the pair was inserted. The returned error matches ErrNilKey,
+// ErrKeyTypeMismatch or ErrBudgetExceeded under errors.Is.
+func (s *SkipList) InsertIfAbsent(key, value interface{}) (bool, error) {
+	if err := s.checkKey(key); err != nil {
+		return false, err
+	}
 
-		s.length++
+	var inserted bool
+	if err := s.withBudget(func() {
+		inserted = s.list.InsertIfAbsent(key, value)
+	}); err != nil {
+		return false, err
+	}
+	if inserted {
+		s.rec.record(opInsertIfAbsent, key, value)
 	}
 
-	return nil
+	return inserted, nil
 }
 
-// Search searches for a key in the skip list and returns the corresponding value
+// Upsert inserts a new key-value pair into the skip list, replacing the
+// value if the key is already present, and reports whether a node was
+// created. Unlike Insert it replaces values in strict mode too. The
+// returned error matches ErrNilKey, ErrKeyTypeMismatch or
+// ErrBudgetExceeded under errors.Is.
+func (s *SkipList) Upsert(key, value interface{}) (bool, error) {
+	if err := s.checkKey(key); err != nil {
+		return false, err
+	}
+
+	var created bool
+	if err := s.withBudget(func() {
+		created = s.list.Upsert(key, value)
+	}); err != nil {
+		return false, err
+	}
+	s.rec.record(opUpsert, key, value)
+
+	return created, nil
+}
+
+// Replace replaces the value stored under key, which must already be
+// present. The returned error matches ErrNilKey, ErrKeyTypeMismatch,
+// ErrBudgetExceeded or ErrKeyNotFound under errors.Is.
+func (s *SkipList) Replace(key, value interface{}) error {
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
+
+	var ok bool
+	if err := s.withBudget(func() {
+		ok = s.list.Replace(key, value)
+	}); err != nil {
+		return err
+	}
+
+	if ok {
+		s.rec.record(opReplace, key, value)
+		return nil
+	}
+
+	return fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+}
+
+// Search searches for a key in the skip list and returns the corresponding value.
+// A key stored with a nil value yields (nil, nil). The returned error matches
+// ErrNilKey, ErrKeyTypeMismatch, ErrBudgetExceeded or ErrKeyNotFound under errors.Is.
 func (s *SkipList) Search(key interface{}) (interface{}, error) {
-	if key == nil {
-		return nil, errors.New("Key cannot be nil")
+	if err := s.checkKey(key); err != nil {
+		return nil, err
 	}
 
-	current := s.head
+	var n *node[interface{}, interface{}]
+	if err := s.withReadBudget(func(compare func(a, b interface{}) int) {
+		n = s.list.findFunc(key, compare)
+	}); err != nil {
+		return nil, err
+	}
 
-	for i := s.level - 1; i >= 0; i-- {
-		for current.forward[i] != nil && s.compare(current.forward[i].key, key) < 0 {
-			current = current.forward[i]
-		}
+	if n != nil {
+		s.rec.record(opSearch, key, nil)
+		return n.value, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+}
+
+// Get returns the value stored under key and whether the key was found.
+// Unlike Search it does not allocate an error when the key is missing;
+// a nil or mismatched key, or a lookup over the comparison budget, is
+// reported as not found.
+func (s *SkipList) Get(key interface{}) (interface{}, bool) {
+	if s.checkKey(key) != nil {
+		return nil, false
 	}
 
-	current = current.forward[0]
+	var n *node[interface{}, interface{}]
+	if s.withReadBudget(func(compare func(a, b interface{}) int) {
+		n = s.list.findFunc(key, compare)
+	}) != nil || n == nil {
+		return nil, false
+	}
+	s.rec.record(opGet, key, nil)
+
+	if s.access != nil {
+		s.access.record(key)
+	}
+	return n.value, true
+}
 
-	if current != nil && s.compare(current.key, key) == 0 {
-		return current.value, nil
+// Contains reports whether key is present in the skip list
+func (s *SkipList) Contains(key interface{}) bool {
+	if s.checkKey(key) != nil {
+		return false
 	}
 
-	return nil, errors.New("Key not found")
+	var n *node[interface{}, interface{}]
+	if s.withReadBudget(func(compare func(a, b interface{}) int) {
+		n = s.list.findFunc(key, compare)
+	}) != nil || n == nil {
+		return false
+	}
+	s.rec.record(opContains, key, nil)
+
+	if s.access != nil {
+		s.access.record(key)
+	}
+	return true
 }
 
-// Delete deletes a key from the skip list
+// Delete deletes a key from the skip list. The returned error matches
+// ErrNilKey, ErrKeyTypeMismatch, ErrBudgetExceeded or ErrKeyNotFound under errors.Is.
 func (s *SkipList) Delete(key interface{}) error {
-	if key == nil {
-		return errors.New("Key cannot be nil")
+	if err := s.checkKey(key); err != nil {
+		return err
 	}
 
-	update := make([]*node, s.level)
-	current := s.head
-
-	for i := s.level - 1; i >= 0; i-- {
-		for current.forward[i] != nil && s.compare(current.forward[i].key, key) < 0 {
-			current = current.forward[i]
-		}
-		update[i] = current
+	var ok bool
+	if err := s.withBudget(func() {
+		ok = s.list.Delete(key)
+	}); err != nil {
+		return err
 	}
 
-	current = current.forward[0]
+	if ok {
+		s.rec.record(opDelete, key, nil)
+		return nil
+	}
 
-	if current != nil && s.compare(current.key, key) == 0 {
-		for i := 0; i < s.level; i++ {
-			if update[i].forward[i] != current {
-				break
-			}
-			update[i].forward[i] = current.forward[i]
-		}
+	return fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+}
 
-		for s.level > 1 && s.head.forward[s.level-1] == nil {
-			s.level--
-		}
+// Update replaces the value stored under key with fn applied to it, in a
+// single lookup. The returned error matches ErrNilKey, ErrKeyTypeMismatch,
+// ErrBudgetExceeded or ErrKeyNotFound under errors.Is; fn is not called then.
+func (s *SkipList) Update(key interface{}, fn func(old interface{}) interface{}) error {
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
 
-		s.length--
+	var value interface{}
+	var ok bool
+	err := s.withBudget(func() {
+		ok = s.list.Update(key, func(old interface{}) interface{} {
+			value = fn(old)
+			return value
+		})
+	})
+	if err != nil {
+		return err
+	}
 
+	if ok {
+		s.rec.record(opUpdate, key, value)
 		return nil
 	}
 
-	return errors.New("Key not found")
+	return fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+}
+
+// Remove deletes a key from the skip list and returns the value it held
+// and whether anything was removed. Removing a missing, nil or mismatched
+// key returns (nil, false).
+func (s *SkipList) Remove(key interface{}) (interface{}, bool) {
+	if s.checkKey(key) != nil {
+		return nil, false
+	}
+
+	var value interface{}
+	var ok bool
+	if s.withBudget(func() {
+		value, ok = s.list.Remove(key)
+	}) != nil || !ok {
+		return nil, false
+	}
+
+	s.rec.record(opRemove, key, nil)
+	return value, true
 }
 
 // Length returns the length of the skip list
 func (s *SkipList) Length() int {
-	return s.length
+	return s.list.Len()
 }
 
 // Iterator returns a new iterator for the skip list
 func (s *SkipList) Iterator() *SkipListIterator {
 	return &SkipListIterator{
-		list:   s,
-		node:   s.head,
-		isHead: true,
+		list: s,
+		it:   s.list.Iterator(),
 	}
 }
 
+// ReverseIterator returns a new iterator for the skip list that starts at
+// the largest key; its Next walks towards smaller keys and its Prev back
+// towards larger ones
+func (s *SkipList) ReverseIterator() *SkipListIterator {
+	return &SkipListIterator{
+		list: s,
+		it:   s.list.ReverseIterator(),
+	}
+}
+
+// Range returns an iterator over the keys in [min, max]. A nil min means
+// from the first key and a nil max means up to the last key. The first call
+// to Next moves to the first key in range and Next returns false once the
+// iterator would pass max. Bounds that fail CheckRange yield an empty
+// iterator whose Err reports why; inverted bounds do so only in strict
+// mode and otherwise select no keys.
+func (s *SkipList) Range(min, max interface{}) *SkipListIterator {
+	it := s.Iterator()
+
+	if err := s.checkBounds(min, max); err != nil {
+		it.it.empty = true
+		it.err = err
+		return it
+	}
+
+	if min != nil {
+		it.it.setLower(min)
+	}
+	if max != nil {
+		it.it.setUpper(max)
+	}
+
+	return it
+}
+
 // Next moves the iterator to the next node in the skip list and returns true if successful
 func (it *SkipListIterator) Next() bool {
-	if it.node.forward[0] != nil {
-		it.node = it.node.forward[0]
-		it.isHead = false
+	return it.it.Next()
+}
+
+// Prev moves the iterator to the previous node in the skip list and returns
+// true if successful. At the first node, or before the first call to Next,
+// it returns false and leaves the iterator where it is.
+func (it *SkipListIterator) Prev() bool {
+	return it.it.Prev()
+}
+
+// Seek positions the iterator at the first node whose key is >= key and
+// returns true if there is one; Next then continues from that node. If
+// there is none, or key is nil or mismatched, the iterator is left
+// exhausted and Next returns false; Err then reports the key error, or in
+// strict mode ErrSeekPastEnd. An empty range iterator stays empty.
+func (it *SkipListIterator) Seek(key interface{}) bool {
+	if it.it.empty {
+		return false
+	}
+	if err := it.list.checkKey(key); err != nil {
+		it.it.node = nil
+		it.it.isHead = false
+		it.err = err
+		return false
+	}
+
+	it.err = nil
+	if it.it.Seek(key) {
 		return true
 	}
+	if it.list.list.opts.strict {
+		it.err = fmt.Errorf("%w: %v", ErrSeekPastEnd, key)
+	}
 	return false
 }
 
+// SeekToLast positions the iterator at the largest key within its bounds
+// and returns true if there is one; Prev then walks towards smaller keys.
+// If there is none, the iterator is left exhausted.
+func (it *SkipListIterator) SeekToLast() bool {
+	return it.it.SeekToLast()
+}
+
+// Err returns the error that left the iterator empty or exhausted: the
+// CheckRange error of the bounds given to Range, the error of the last key
+// given to Seek, or in strict mode ErrSeekPastEnd. It returns nil if there
+// was none.
+func (it *SkipListIterator) Err() error {
+	return it.err
+}
+
+// Valid reports whether the iterator is positioned at a node
+func (it *SkipListIterator) Valid() bool {
+	return it.it.Valid()
+}
+
 // Key returns the key of the current node being iterated
 func (it *SkipListIterator) Key() interface{} {
-	if it.isHead {
-		return nil
-	}
-	return it.node.key
+	return it.it.Key()
 }
 
-// Value returns the value of the current node being iterated
+// Value returns the value of the current node being iterated.
+// Value is also nil before the first call to Next; since stored keys are
+// never nil, Key can be used to tell that apart from a stored nil value.
 func (it *SkipListIterator) Value() interface{} {
-	if it.isHead {
-		return nil
-	}
-	return it.node.value
+	return it.it.Value()
 }
 
 // Clear Reset resets the iterator to the beginning of the skip list
 func (s *SkipList) Clear() {
-	s.head.forward = make([]*node, DefaultMaxLevel)
-	s.level = 1
-	s.length = 0
+	s.rec.recordOp(opClear)
+	s.list.Clear()
 }
 
 
 // MinString returns the minimum string key in the skip list,
 // along with a boolean indicating if a key was found.
+// It is First restricted to lists whose keys are strings.
 func (s *SkipList) MinString() (string, bool) {
-	if s.length == 0 {
+	key, _, ok := s.First()
+	if !ok {
 		return "", false
 	}
 
-	current := s.head.forward[0]
-	minKey := ""
-	for current != nil {
-		if key, ok := current.key.(string); ok {
-			if minKey == "" || key < minKey {
-				minKey = key
-			}
-		}
-		current = current.forward[0]
-	}
-
-	if minKey != "" {
-		return minKey, true
+	if key, ok := key.(string); ok {
+		return key, true
 	}
 
 	return "", false
@@ -347,24 +713,15 @@ func (s *SkipList) MinString() (string, bool) {
 
 // MaxString returns the maximum string key in the skip list,
 // along with a boolean indicating if a key was found.
+// It is Last restricted to lists whose keys are strings.
 func (s *SkipList) MaxString() (string, bool) {
-	if s.length == 0 {
+	key, _, ok := s.Last()
+	if !ok {
 		return "", false
 	}
 
-	current := s.head.forward[0]
-	maxKey := ""
-	for current != nil {
-		if key, ok := current.key.(string); ok {
-			if maxKey == "" || key > maxKey {
-				maxKey = key
-			}
-		}
-		current = current.forward[0]
-	}
-
-	if maxKey != "" {
-		return maxKey, true
+	if key, ok := key.(string); ok {
+		return key, true
 	}
 
 	return "", false
@@ -372,19 +729,14 @@ func (s *SkipList) MaxString() (string, bool) {
 
 // MaxInt returns the maximum int key in the skip list,
 // along with a boolean indicating if a key was found.
+// It is Last restricted to lists whose keys are ints.
 func (s *SkipList) MaxInt() (int, bool) {
-	if s.length == 0 {
+	key, _, ok := s.Last()
+	if !ok {
 		return 0, false
 	}
 
-	current := s.head
-	for i := s.level - 1; i >= 0; i-- {
-		for current.forward[i] != nil && current.forward[i] != s.head {
-			current = current.forward[i]
-		}
-	}
-
-	if key, ok := current.key.(int); ok {
+	if key, ok := key.(int); ok {
 		return key, true
 	}
 
@@ -393,17 +745,15 @@ func (s *SkipList) MaxInt() (int, bool) {
 
 // MinInt returns the minimum int key in the skip list,
 // along with a boolean indicating if a key was found.
+// It is First restricted to lists whose keys are ints.
 func (s *SkipList) MinInt() (int, bool) {
-	if s.length == 0 {
+	key, _, ok := s.First()
+	if !ok {
 		return 0, false
 	}
 
-	current := s.head.forward[0]
-	for current != nil && current != s.head {
-		if key, ok := current.key.(int); ok {
-			return key, true
-		}
-		current = current.forward[0]
+	if key, ok := key.(int); ok {
+		return key, true
 	}
 
 	return 0, false
@@ -411,76 +761,110 @@ func (s *SkipList) MinInt() (int, bool) {
 
 // SortByValue returns a slice of values in the skip list sorted by their values.
 // If reverse is true, the values are sorted in descending order; otherwise,
-// they are sorted in ascending order.
+// they are sorted in ascending order. Nil values sort before all other values
+// and equal values keep their key order. The built-in ordering covers
+// values that all have the same one of the ordered key types: the signed
+// and unsigned integers, uintptr, float32, float64 and string or a type
+// defined over one of them, with NaN sorting before any other float. Mixed
+// or other values are returned in key order, and SortByValueFunc sorts them
+// with a custom ordering.
 func (s *SkipList) SortByValue(reverse bool) []interface{} {
-	if s.length == 0 {
+	if s.list.length == 0 {
 		return nil
 	}
 
-	current := s.head.forward[0]
-	result := make([]interface{}, 0, s.length)
+	result := s.ValuesSlice()
+	if !comparableValues(result) {
+		return result
+	}
+
+	sortStable(result, compareKeysOrValues, reverse)
+	return result
+}
 
-	for current != nil {
-		result = append(result, current.value)
-		current = current.forward[0]
+// SortByValueFunc returns a slice of values in the skip list sorted by less.
+// If reverse is true, the values are sorted in descending order; otherwise,
+// they are sorted in ascending order. Equal values keep their key order.
+func (s *SkipList) SortByValueFunc(less func(a, b interface{}) bool, reverse bool) []interface{} {
+	if s.list.length == 0 {
+		return nil
 	}
 
+	result := s.ValuesSlice()
+	sortStable(result, less, reverse)
+	return result
+}
+
+// sortStable sorts values by less, or in reverse if reverse is set,
+// keeping the order of equal values
+func sortStable(values []interface{}, less func(a, b interface{}) bool, reverse bool) {
 	if reverse {
-		sort.Slice(result, func(i, j int) bool {
-			return compareKeysOrValues(result[j], result[i])
+		sort.SliceStable(values, func(i, j int) bool {
+			return less(values[j], values[i])
 		})
 	} else {
-		sort.Slice(result, func(i, j int) bool {
-			return compareKeysOrValues(result[i], result[j])
+		sort.SliceStable(values, func(i, j int) bool {
+			return less(values[i], values[j])
 		})
 	}
+}
 
-	return result
+// comparableValues reports whether compareKeysOrValues orders values,
+// that is whether the non-nil values are all of one of its types
+func comparableValues(values []interface{}) bool {
+	var kind reflect.Type
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if !isOrdered(v) {
+			return false
+		}
+		if kind == nil {
+			kind = reflect.TypeOf(v)
+		} else if reflect.TypeOf(v) != kind {
+			return false
+		}
+	}
+	return true
 }
 
 // SortByKey returns a slice of keys in the skip list sorted by their keys.
 // If reverse is true, the keys are sorted in descending order; otherwise,
-// they are sorted in ascending order.
+// they are sorted in ascending order. The keys are already kept in order,
+// so no sorting is done.
 func (s *SkipList) SortByKey(reverse bool) []interface{} {
-	if s.length == 0 {
+	if s.list.length == 0 {
 		return nil
 	}
 
-	current := s.head.forward[0]
-	result := make([]interface{}, 0, s.length)
-
-	for current != nil {
-		result = append(result, current.key)
-		current = current.forward[0]
-	}
-
+	result := s.KeysSlice()
 	if reverse {
-		sort.Slice(result, func(i, j int) bool {
-			return compareKeysOrValues(result[j], result[i])
-		})
-	} else {
-		sort.Slice(result, func(i, j int) bool {
-			return compareKeysOrValues(result[i], result[j])
-		})
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
 	}
 
 	return result
 }
 
-// compareKeysOrValues compares two keys or values for sorting purposes.
-// It supports comparison of int and string types.
-func compareKeysOrValues(a, b interface{}) bool {
-	intA, okA := a.(int)
-	intB, okB := b.(int)
-	if okA && okB {
-		return intA < intB
+// isOrdered reports whether v has one of the types ordered by compareOrdered
+func isOrdered(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, string, float64, float32:
+		return true
+	default:
+		return orderedType(reflect.TypeOf(v))
 	}
+}
 
-	strA, okA := a.(string)
-	strB, okB := b.(string)
-	if okA && okB {
-		return strA < strB
+// compareKeysOrValues compares two keys or values for sorting purposes.
+// It supports the built-in integer, float and string types; nil sorts before
+// any non-nil value and NaN before any other float.
+func compareKeysOrValues(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
 	}
 
-	return false
+	return compareOrdered(a, b) < 0
 }
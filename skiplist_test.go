@@ -0,0 +1,565 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRangeInvalidBoundsStaysEmpty(t *testing.T) {
+	s := NewSkipList(Int)
+	for i := 0; i < 10; i++ {
+		s.Insert(i, i)
+	}
+
+	for _, bounds := range [][2]interface{}{{"a", 5}, {2, "z"}, {2.5, nil}} {
+		it := s.Range(bounds[0], bounds[1])
+		if it.SeekToLast() {
+			t.Fatalf("Range(%v, %v).SeekToLast moved to %v", bounds[0], bounds[1], it.Key())
+		}
+		if it.Seek(3) {
+			t.Fatalf("Range(%v, %v).Seek(3) moved to %v", bounds[0], bounds[1], it.Key())
+		}
+		if it.Next() || it.Prev() || it.Valid() {
+			t.Fatalf("Range(%v, %v) is not empty", bounds[0], bounds[1])
+		}
+	}
+}
+
+func TestMaxInt(t *testing.T) {
+	if _, ok := NewSkipList(Int).MaxInt(); ok {
+		t.Fatal("MaxInt found a key in an empty list")
+	}
+
+	lowTail := 0
+	for seed := int64(0); seed < 50; seed++ {
+		s, err := New(Int, WithRandSource(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rng := rand.New(rand.NewSource(seed))
+		want := math.MinInt
+		for i := 0; i < 1+rng.Intn(200); i++ {
+			key := rng.Intn(10000) - 5000
+			s.Insert(key, nil)
+			want = max(want, key)
+		}
+		if len(s.list.tail.forward) < s.list.level {
+			lowTail++
+		}
+
+		if got, ok := s.MaxInt(); !ok || got != want {
+			t.Fatalf("Seed %d: MaxInt() = %d, %v, want %d, true", seed, got, ok, want)
+		}
+	}
+	if lowTail == 0 {
+		t.Fatal("No list had a max key below the top level")
+	}
+}
+
+func TestMinMaxKeys(t *testing.T) {
+	empty := NewSkipList(String)
+	if _, ok := empty.MinString(); ok {
+		t.Error("MinString found a key in an empty list")
+	}
+	if _, ok := empty.MaxString(); ok {
+		t.Error("MaxString found a key in an empty list")
+	}
+	if _, ok := NewSkipList(Int).MinInt(); ok {
+		t.Error("MinInt found a key in an empty list")
+	}
+
+	strs := NewSkipList(String)
+	for _, key := range []string{"m", "b", "z", "a", "q"} {
+		strs.Insert(key, nil)
+	}
+	if got, ok := strs.MinString(); !ok || got != "a" {
+		t.Errorf("MinString() = %q, %v, want a, true", got, ok)
+	}
+	if got, ok := strs.MaxString(); !ok || got != "z" {
+		t.Errorf("MaxString() = %q, %v, want z, true", got, ok)
+	}
+	if _, ok := strs.MinInt(); ok {
+		t.Error("MinInt found a key in a list of strings")
+	}
+
+	ints := NewSkipList(Int)
+	for _, key := range []int{7, -3, 12, 0} {
+		ints.Insert(key, nil)
+	}
+	if got, ok := ints.MinInt(); !ok || got != -3 {
+		t.Errorf("MinInt() = %d, %v, want -3, true", got, ok)
+	}
+	if _, ok := ints.MaxString(); ok {
+		t.Error("MaxString found a key in a list of ints")
+	}
+}
+
+func TestNilValues(t *testing.T) {
+	s := NewSkipList(Int)
+	for i, value := range []interface{}{"a", nil, "c", nil} {
+		if err := s.Insert(i+1, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wantValues := []interface{}{"a", nil, "c", nil}
+
+	// check fails t unless l holds keys 1 to 4 with wantValues on every read path
+	check := func(path string, l *SkipList) {
+		t.Helper()
+		if l.Length() != 4 {
+			t.Fatalf("%s: Length() = %d, want 4", path, l.Length())
+		}
+		if v, err := l.Search(2); err != nil || v != nil {
+			t.Fatalf("%s: Search(2) = %v, %v, want <nil>, <nil>", path, v, err)
+		}
+		if v, ok := l.Get(4); !ok || v != nil {
+			t.Fatalf("%s: Get(4) = %v, %v, want <nil>, true", path, v, ok)
+		}
+		if !l.Contains(2) {
+			t.Fatalf("%s: Contains(2) = false for a nil value", path)
+		}
+		if got := l.ValuesSlice(); !reflect.DeepEqual(got, wantValues) {
+			t.Fatalf("%s: ValuesSlice() = %v, want %v", path, got, wantValues)
+		}
+		entries := l.Entries()
+		if len(entries) != 4 || entries[1].Value != nil || entries[1].Key != 2 {
+			t.Fatalf("%s: Entries() = %v", path, entries)
+		}
+		var values []interface{}
+		for it := l.Iterator(); it.Next(); {
+			values = append(values, it.Value())
+		}
+		if !reflect.DeepEqual(values, wantValues) {
+			t.Fatalf("%s: Iterator yielded %v, want %v", path, values, wantValues)
+		}
+		values = values[:0]
+		for _, v := range l.All() {
+			values = append(values, v)
+		}
+		if !reflect.DeepEqual(values, wantValues) {
+			t.Fatalf("%s: All yielded %v, want %v", path, values, wantValues)
+		}
+		if got, want := l.SortByValue(false), []interface{}{nil, nil, "a", "c"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: SortByValue(false) = %v, want %v", path, got, want)
+		}
+	}
+	check("SkipList", s)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := NewSkipList(Int)
+	if err := json.Unmarshal(data, fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	check("JSON", fromJSON)
+
+	var buf bytes.Buffer
+	if err := s.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fromBinary, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("Encode", fromBinary)
+
+	data, err = s.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromGob := NewSkipList(Int)
+	if err := fromGob.GobDecode(data); err != nil {
+		t.Fatal(err)
+	}
+	check("Gob", fromGob)
+}
+
+func TestMixedKeyTypes(t *testing.T) {
+	s := NewSkipList(Int)
+	for i := 0; i < 100; i += 2 {
+		s.Insert(i, i)
+	}
+
+	for _, key := range []interface{}{"hello", 3.5, int64(4), uint(6), []int{1}} {
+		err := s.Insert(key, "bad")
+		if !errors.Is(err, ErrKeyTypeMismatch) {
+			t.Fatalf("Insert(%#v) = %v, want ErrKeyTypeMismatch", key, err)
+		}
+		if msg := err.Error(); !strings.Contains(msg, "int") || !strings.Contains(msg, fmt.Sprintf("%T", key)) {
+			t.Fatalf("Insert(%#v) error %q does not name both types", key, msg)
+		}
+		if _, err := s.Search(key); !errors.Is(err, ErrKeyTypeMismatch) {
+			t.Fatalf("Search(%#v) = %v, want ErrKeyTypeMismatch", key, err)
+		}
+		if err := s.Delete(key); !errors.Is(err, ErrKeyTypeMismatch) {
+			t.Fatalf("Delete(%#v) = %v, want ErrKeyTypeMismatch", key, err)
+		}
+		if _, ok := s.Get(key); ok {
+			t.Fatalf("Get(%#v) reported a key", key)
+		}
+	}
+
+	verify(t, s.list)
+	if s.Length() != 50 {
+		t.Fatalf("Length() = %d after rejected inserts, want 50", s.Length())
+	}
+
+	// Without a key type the first key sets the type of the others.
+	untyped := NewSkipList(nil)
+	if err := untyped.Insert(1, 1); err != nil {
+		t.Fatalf("Insert(1) into a list without key type = %v", err)
+	}
+	for _, key := range []interface{}{"a", 2.5} {
+		if err := untyped.Insert(key, key); !errors.Is(err, ErrKeyTypeMismatch) {
+			t.Fatalf("Insert(%#v) into a list holding ints = %v, want ErrKeyTypeMismatch", key, err)
+		}
+	}
+	if err := untyped.Insert(2, 2); err != nil {
+		t.Fatalf("Insert(2) into a list holding ints = %v", err)
+	}
+	if untyped.Length() != 2 {
+		t.Fatalf("Length() = %d, want 2", untyped.Length())
+	}
+	if err := untyped.BulkInsert([]interface{}{"b", "c"}, []interface{}{nil, nil}); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("BulkInsert of strings into a list holding ints = %v, want ErrKeyTypeMismatch", err)
+	}
+	verify(t, untyped.list)
+
+	// An emptied list takes a new key type, but a batch must agree with itself.
+	untyped.Clear()
+	if err := untyped.Insert("a", 1); err != nil {
+		t.Fatalf("Insert(\"a\") into an emptied list = %v", err)
+	}
+	if err := NewSkipList(nil).BulkInsert([]interface{}{1, "a"}, []interface{}{nil, nil}); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("BulkInsert of mixed keys = %v, want ErrKeyTypeMismatch", err)
+	}
+
+	// Key types that cannot be ordered are rejected rather than collapsing
+	// every key onto one.
+	type point struct{ x, y int }
+	for _, key := range []interface{}{[]byte("a"), point{1, 2}, map[int]int{}} {
+		if _, err := New(reflect.TypeOf(key)); !errors.Is(err, ErrUnorderedKeyType) {
+			t.Fatalf("New(%T) = %v, want ErrUnorderedKeyType", key, err)
+		}
+		if err := NewSkipList(nil).Insert(key, 1); !errors.Is(err, ErrUnorderedKeyType) {
+			t.Fatalf("Insert(%T) into a list without key type = %v, want ErrUnorderedKeyType", key, err)
+		}
+	}
+	if !mustPanic(func() { NewSkipList(reflect.TypeOf([]byte(nil))) }) {
+		t.Fatal("NewSkipList accepted a slice key type")
+	}
+}
+
+// ID is a named integer key type, ordered like int
+type ID int
+
+func TestIntegerWidthKeys(t *testing.T) {
+	for _, keyType := range []reflect.Type{Int64, Uint64, reflect.TypeOf(int8(0)), reflect.TypeOf(uint16(0)), reflect.TypeOf(ID(0))} {
+		s, err := New(keyType)
+		if err != nil {
+			t.Fatalf("New(%v) = %v", keyType, err)
+		}
+		for _, i := range []int{5, 3, 1, 4, 2} {
+			key := reflect.ValueOf(i).Convert(keyType).Interface()
+			if err := s.Insert(key, i); err != nil {
+				t.Fatalf("%v: Insert(%v) = %v", keyType, key, err)
+			}
+		}
+
+		if s.Length() != 5 {
+			t.Fatalf("%v: Length() = %d after 5 distinct keys, want 5", keyType, s.Length())
+		}
+		verify(t, s.list)
+		for i, v := range s.ValuesSlice() {
+			if v != i+1 {
+				t.Fatalf("%v: ValuesSlice() = %v, want keys in ascending order", keyType, s.ValuesSlice())
+			}
+		}
+		key := reflect.ValueOf(3).Convert(keyType).Interface()
+		if v, ok := s.Get(key); !ok || v != 3 {
+			t.Fatalf("%v: Get(%v) = %v, %v, want 3, true", keyType, key, v, ok)
+		}
+	}
+
+	// A named type is a type of its own, not its underlying type.
+	s := NewSkipList(reflect.TypeOf(ID(0)))
+	if err := s.Insert(1, nil); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("Insert(int) into an ID list = %v, want ErrKeyTypeMismatch", err)
+	}
+	untyped := NewSkipList(nil)
+	for _, key := range []ID{3, 1, 2} {
+		untyped.Insert(key, nil)
+	}
+	if got := untyped.KeysSlice(); !reflect.DeepEqual(got, []interface{}{ID(1), ID(2), ID(3)}) {
+		t.Fatalf("KeysSlice() = %v, want [1 2 3]", got)
+	}
+}
+
+func TestSortByValue(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []interface{} // Values of keys 0, 1, 2, ...
+		want   []interface{} // Ascending order; reversed for reverse, except values kept in key order
+		sorted bool          // Whether the values are ordered rather than kept in key order
+	}{
+		{"ints", []interface{}{3, 1, 2, 1}, []interface{}{1, 1, 2, 3}, true},
+		{"strings with nil", []interface{}{"b", nil, "a"}, []interface{}{nil, "a", "b"}, true},
+		{"floats with NaN", []interface{}{2.5, nan, -1.0}, []interface{}{nan, -1.0, 2.5}, true},
+		{"named ints", []interface{}{ID(2), ID(1)}, []interface{}{ID(1), ID(2)}, true},
+		{"only nil", []interface{}{nil, nil}, []interface{}{nil, nil}, true},
+		{"int and string", []interface{}{2, "a", 1}, []interface{}{2, "a", 1}, false},
+		{"int and int64", []interface{}{int64(2), 1}, []interface{}{int64(2), 1}, false},
+		{"int and named int", []interface{}{ID(2), 1}, []interface{}{ID(2), 1}, false},
+		{"slices", []interface{}{[]int{2}, []int{1}}, []interface{}{[]int{2}, []int{1}}, false},
+		{"structs", []interface{}{struct{ A int }{2}, struct{ A int }{1}}, []interface{}{struct{ A int }{2}, struct{ A int }{1}}, false},
+		{"maps and nil", []interface{}{map[int]int{}, nil}, []interface{}{map[int]int{}, nil}, false},
+	}
+	for _, tt := range tests {
+		s := NewSkipList(Int)
+		for i, v := range tt.values {
+			s.Insert(i, v)
+		}
+
+		if got := s.SortByValue(false); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: SortByValue(false) = %v, want %v", tt.name, got, tt.want)
+		}
+		want := tt.want
+		if tt.sorted {
+			want = make([]interface{}, len(tt.want))
+			for i, v := range tt.want {
+				want[len(want)-1-i] = v
+			}
+		}
+		if got := s.SortByValue(true); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: SortByValue(true) = %v, want %v", tt.name, got, want)
+		}
+	}
+
+	if got := NewSkipList(Int).SortByValue(false); got != nil {
+		t.Errorf("SortByValue of an empty list = %v, want nil", got)
+	}
+}
+
+func TestContainsMatchesSearch(t *testing.T) {
+	s := NewSkipList(Int)
+	for i := 0; i < 100; i += 2 {
+		s.Insert(i, nil)
+	}
+
+	for key := -1; key <= 100; key++ {
+		_, err := s.Search(key)
+		if found := s.Contains(key); found != (err == nil) {
+			t.Fatalf("Contains(%d) = %t, but Search returned %v", key, found, err)
+		}
+	}
+	if s.Contains(nil) || s.Contains("a") {
+		t.Fatal("Contains reported a nil or mismatched key as present")
+	}
+
+	// Unlike Search, a miss builds no error.
+	if allocs := testing.AllocsPerRun(100, func() { s.Contains(51) }); allocs != 0 {
+		t.Fatalf("Contains of a missing key made %v allocations, want 0", allocs)
+	}
+}
+
+func TestHeadSizedToMaxLevel(t *testing.T) {
+	for _, maxLevel := range []int{1, 2, 5, DefaultMaxLevel} {
+		s, err := New(Int, WithMaxLevel(maxLevel), WithRandSource(rand.NewSource(1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for round := 0; round < 2; round++ {
+			if got := len(s.list.head.forward); got != maxLevel {
+				t.Fatalf("Max level %d, round %d: head has %d levels", maxLevel, round, got)
+			}
+			// Enough keys to raise the level right away.
+			for i := 0; i < 1000; i++ {
+				s.Insert(i, i)
+			}
+			verify(t, s.list)
+			if maxLevel > 1 && s.list.level < 2 {
+				t.Fatalf("Max level %d, round %d: 1000 keys stayed at level 1", maxLevel, round)
+			}
+			s.Clear()
+		}
+	}
+}
+
+func TestReverseIterator(t *testing.T) {
+	s, _ := New(Int, WithRandSource(rand.NewSource(1)))
+	rng := rand.New(rand.NewSource(2))
+	present := make(map[int]bool)
+	for step := 0; step < 2000; step++ {
+		key := rng.Intn(200)
+		if rng.Intn(3) == 0 {
+			s.Delete(key)
+			delete(present, key)
+		} else {
+			s.Insert(key, key)
+			present[key] = true
+		}
+
+		// Now and then delete the tail itself.
+		if step%100 == 99 {
+			last, _, ok := s.Last()
+			if ok {
+				s.Delete(last)
+				delete(present, last.(int))
+			}
+		}
+
+		if step%50 != 49 {
+			continue
+		}
+		verify(t, s.list)
+		want := 199
+		for it := s.ReverseIterator(); it.Next(); want-- {
+			for want >= 0 && !present[want] {
+				want--
+			}
+			if it.Key() != want {
+				t.Fatalf("Step %d: ReverseIterator yielded %v, want %d", step, it.Key(), want)
+			}
+		}
+		for want >= 0 && !present[want] {
+			want--
+		}
+		if want >= 0 {
+			t.Fatalf("Step %d: ReverseIterator stopped before %d", step, want)
+		}
+	}
+
+	s.Clear()
+	if s.ReverseIterator().Next() {
+		t.Fatal("ReverseIterator of a cleared list yielded a key")
+	}
+	s.Insert(1, 1)
+	if it := s.ReverseIterator(); !it.Next() || it.Key() != 1 || it.Next() {
+		t.Fatal("ReverseIterator after Clear and Insert did not yield just the new key")
+	}
+}
+
+func TestSortByValueFunc(t *testing.T) {
+	type score struct {
+		Name   string
+		Points float64
+	}
+	s := NewSkipList(Int)
+	for i, v := range []score{{"a", 2.5}, {"b", 1}, {"c", 2.5}, {"d", -3}} {
+		s.Insert(i, v)
+	}
+	byPoints := func(a, b interface{}) bool { return a.(score).Points < b.(score).Points }
+
+	names := func(values []interface{}) string {
+		var b strings.Builder
+		for _, v := range values {
+			b.WriteString(v.(score).Name)
+		}
+		return b.String()
+	}
+	// Equal values keep key order both ways: a before c.
+	if got := names(s.SortByValueFunc(byPoints, false)); got != "dbac" {
+		t.Errorf("SortByValueFunc ascending = %s, want dbac", got)
+	}
+	if got := names(s.SortByValueFunc(byPoints, true)); got != "acbd" {
+		t.Errorf("SortByValueFunc descending = %s, want acbd", got)
+	}
+
+	floats := NewSkipList(Int)
+	for i, v := range []float64{0.5, -1, 2, 0.25} {
+		floats.Insert(i, v)
+	}
+	got := floats.SortByValueFunc(func(a, b interface{}) bool { return a.(float64) < b.(float64) }, false)
+	if want := []interface{}{-1.0, 0.25, 0.5, 2.0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortByValueFunc of floats = %v, want %v", got, want)
+	}
+	if got := NewSkipList(Int).SortByValueFunc(byPoints, false); got != nil {
+		t.Errorf("SortByValueFunc of an empty list = %v, want nil", got)
+	}
+}
+
+// BenchmarkMiss looks up missing keys with Contains, which reports a miss
+// without building an error, and with Search
+func BenchmarkMiss(b *testing.B) {
+	s := NewSkipList(Int)
+	misses := make([]interface{}, 1024)
+	for i := range misses {
+		s.Insert(i*2, i)
+		misses[i] = i*2 + 1
+	}
+
+	b.Run("Contains", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if s.Contains(misses[i%len(misses)]) {
+				b.Fatal("Contains found a missing key")
+			}
+		}
+	})
+	b.Run("Search", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := s.Search(misses[i%len(misses)]); err == nil {
+				b.Fatal("Search found a missing key")
+			}
+		}
+	})
+}
+
+// scanMinMaxString is MinString and MaxString as they were before reading
+// the ordered structure: a walk over every key of level 0. It is the
+// baseline of BenchmarkMinMaxString.
+func scanMinMaxString(s *SkipList) (string, string) {
+	minKey, maxKey := "", ""
+	for current := s.list.head.forward[0]; current != nil; current = current.forward[0] {
+		if key, ok := current.key.(string); ok {
+			if minKey == "" || key < minKey {
+				minKey = key
+			}
+			if maxKey == "" || key > maxKey {
+				maxKey = key
+			}
+		}
+	}
+	return minKey, maxKey
+}
+
+func BenchmarkMinMaxString(b *testing.B) {
+	for _, n := range []int{1000, 100000} {
+		s := NewSkipList(String)
+		for i := 0; i < n; i++ {
+			s.Insert(fmt.Sprintf("key%08d", i), i)
+		}
+		wantMin, wantMax := fmt.Sprintf("key%08d", 0), fmt.Sprintf("key%08d", n-1)
+
+		b.Run(fmt.Sprintf("Scan/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if lo, hi := scanMinMaxString(s); lo != wantMin || hi != wantMax {
+					b.Fatalf("Scan found %q and %q", lo, hi)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Ordered/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				lo, _ := s.MinString()
+				hi, _ := s.MaxString()
+				if lo != wantMin || hi != wantMax {
+					b.Fatalf("MinString and MaxString found %q and %q", lo, hi)
+				}
+			}
+		})
+	}
+}
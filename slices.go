@@ -0,0 +1,32 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// Entries returns a copy of the key-value pairs of the skip list in
+// ascending key order
+func (s *SkipList) Entries() []Entry {
+	entries := make([]Entry, 0, s.list.length)
+	for n := s.list.head.forward[0]; n != nil; n = n.forward[0] {
+		entries = append(entries, Entry{Key: n.key, Value: n.value})
+	}
+	return entries
+}
+
+// KeysSlice returns a copy of the keys of the skip list in ascending order
+func (s *SkipList) KeysSlice() []interface{} {
+	keys := make([]interface{}, 0, s.list.length)
+	for n := s.list.head.forward[0]; n != nil; n = n.forward[0] {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// ValuesSlice returns a copy of the values of the skip list in ascending key order
+func (s *SkipList) ValuesSlice() []interface{} {
+	values := make([]interface{}, 0, s.list.length)
+	for n := s.list.head.forward[0]; n != nil; n = n.forward[0] {
+		values = append(values, n.value)
+	}
+	return values
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSlices(t *testing.T) {
+	type point struct{ X, Y float64 }
+
+	s := NewSkipList(Int)
+	if len(s.Entries()) != 0 || len(s.KeysSlice()) != 0 || len(s.ValuesSlice()) != 0 {
+		t.Fatal("An empty list has entries")
+	}
+
+	keys := rand.New(rand.NewSource(1)).Perm(100)
+	for _, key := range keys {
+		// Values of any type are returned as stored.
+		if key%2 == 0 {
+			s.Insert(key, float64(key)/4)
+		} else {
+			s.Insert(key, point{float64(key), -float64(key)})
+		}
+	}
+	slices.Sort(keys)
+
+	entries, gotKeys, gotValues := s.Entries(), s.KeysSlice(), s.ValuesSlice()
+	if len(entries) != len(keys) || len(gotKeys) != len(keys) || len(gotValues) != len(keys) {
+		t.Fatalf("Got %d entries, %d keys and %d values, want %d", len(entries), len(gotKeys), len(gotValues), len(keys))
+	}
+	for i, key := range keys {
+		var want interface{} = point{float64(key), -float64(key)}
+		if key%2 == 0 {
+			want = float64(key) / 4
+		}
+		if entries[i] != (Entry{Key: key, Value: want}) || gotKeys[i] != key || gotValues[i] != want {
+			t.Fatalf("Index %d: entry %v, key %v, value %v, want %d, %v", i, entries[i], gotKeys[i], gotValues[i], key, want)
+		}
+	}
+	if !reflect.DeepEqual(s.SortByKey(false), gotKeys) {
+		t.Error("SortByKey(false) does not match KeysSlice")
+	}
+
+	// The slices are copies.
+	gotKeys[0], gotValues[0], entries[0].Value = -1, "changed", "changed"
+	if key, value, _ := s.First(); key != 0 || value != 0.0 {
+		t.Errorf("Modifying the slices changed the first entry to %v, %v", key, value)
+	}
+}
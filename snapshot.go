@@ -0,0 +1,27 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// SnapshotIterator returns an iterator over a copy of the skip list taken
+// when it is called. It yields exactly the entries present at that moment,
+// whatever happens to the list afterwards, at the cost of copying every
+// node in O(n). Values are copied as Clone copies them.
+func (l *List[K, V]) SnapshotIterator() *ListIterator[K, V] {
+	return l.Clone().Iterator()
+}
+
+// SnapshotIterator returns an iterator over a copy of the skip list taken
+// when it is called, in O(n). Unlike Iterator, it yields exactly the
+// entries present at that moment even if the skip list is modified or
+// cleared while it is in use. Values are copied as Clone copies them.
+func (s *SkipList) SnapshotIterator() *SkipListIterator {
+	return s.Clone().Iterator()
+}
+
+// SnapshotIterator returns an iterator over a copy of the skip list taken
+// when it is called. Unlike Iterator, it holds no lock once it returns, so
+// writers may proceed while it is in use.
+func (c *ConcurrentSkipList) SnapshotIterator() *SkipListIterator {
+	return c.Clone().list.Iterator()
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIteratorsDuringMutation(t *testing.T) {
+	l, err := NewList[int, string]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		l.Insert(i*10, "old")
+	}
+
+	live, snap := l.Iterator(), l.SnapshotIterator()
+	var liveKeys, snapKeys []int
+	for step := 0; ; step++ {
+		liveMoved, snapMoved := live.Next(), snap.Next()
+		if !liveMoved && !snapMoved {
+			break
+		}
+		if liveMoved {
+			liveKeys = append(liveKeys, live.Key())
+		}
+		if snapMoved {
+			snapKeys = append(snapKeys, snap.Key())
+			if snap.Value() != "old" {
+				t.Fatalf("Snapshot yielded %v = %q, want the value at the time of the snapshot", snap.Key(), snap.Value())
+			}
+		}
+
+		switch step {
+		case 1:
+			// Ahead of both iterators, and behind them.
+			l.Insert(55, "new")
+			l.Insert(5, "new")
+			l.Delete(70)
+			l.Update(80, func(string) string { return "new" })
+		case 3:
+			// The node the live iterator stands on.
+			l.Delete(30)
+		}
+		verify(t, l)
+	}
+
+	if want := []int{0, 10, 20, 30, 40, 50, 55, 60, 80, 90}; !reflect.DeepEqual(liveKeys, want) {
+		t.Fatalf("Iterator yielded %v, want %v", liveKeys, want)
+	}
+	if want := []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}; !reflect.DeepEqual(snapKeys, want) {
+		t.Fatalf("SnapshotIterator yielded %v, want %v", snapKeys, want)
+	}
+
+	// Clearing the list does not affect a snapshot taken before.
+	snap = l.SnapshotIterator()
+	l.Clear()
+	count := 0
+	for snap.Next() {
+		count++
+	}
+	if count != 10 {
+		t.Fatalf("SnapshotIterator yielded %d keys after Clear, want 10", count)
+	}
+}
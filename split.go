@@ -0,0 +1,133 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "math/rand"
+
+// split cuts every level of the skip list before the first key >= key and
+// returns the nodes from there on as a new list ordered by compare
+func (l *List[K, V]) split(key K, compare func(a, b K) int) *List[K, V] {
+	update := make([]*node[K, V], l.level)
+	rank := make([]int, l.level)
+	first := l.path(key, update, rank)
+	kept := rank[0]
+
+	r := &List[K, V]{
+		head: &node[K, V]{
+			forward: make([]*node[K, V], len(l.head.forward)),
+			span:    make([]int, len(l.head.forward)),
+		},
+		level:   1,
+		compare: compare,
+		opts:    l.opts,
+		// Seeded from the original so that a seeded list splits deterministically.
+		rng: rand.New(rand.NewSource(l.rng.Int63())),
+	}
+	if l.order != nil {
+		r.order = newInsertionOrder[K, V](l.order.moveOnOverwrite)
+	}
+	if first == nil {
+		return r
+	}
+
+	// The insertion order is split by walking it, which is linear in the
+	// number of keys rather than logarithmic. The nodes to move are picked
+	// before any link is cut, so that a panicking compare leaves l intact.
+	var moved []*node[K, V]
+	if l.order != nil {
+		for n := l.order.oldest; n != nil; n = n.newer {
+			if compare(n.key, key) >= 0 {
+				moved = append(moved, n)
+			}
+		}
+	}
+
+	// Likewise the values are cloned before they move, in O(n) as well.
+	var copies []V
+	if copyValue := l.valueCloner(); copyValue != nil {
+		for n := first; n != nil; n = n.forward[0] {
+			copies = append(copies, copyValue(n.value))
+		}
+	}
+
+	for i := 0; i < l.level; i++ {
+		r.head.forward[i] = update[i].forward[i]
+		r.head.span[i] = rank[i] + update[i].span[i] - kept
+		if r.head.forward[i] != nil {
+			r.level = i + 1
+		}
+
+		update[i].forward[i] = nil
+		update[i].span[i] = kept - rank[i]
+	}
+
+	first.backward = nil
+	r.tail = l.tail
+	if update[0] != l.head {
+		l.tail = update[0]
+	} else {
+		l.tail = nil
+	}
+
+	r.length = l.length - kept
+	l.length = kept
+	for l.level > 1 && l.head.forward[l.level-1] == nil {
+		l.level--
+	}
+
+	for _, n := range moved {
+		l.order.remove(n)
+		r.order.push(n)
+	}
+	if copies != nil {
+		i := 0
+		for n := r.head.forward[0]; n != nil; n = n.forward[0] {
+			n.value = copies[i]
+			i++
+		}
+	}
+
+	l.mods++
+	r.mods++
+	return r
+}
+
+// Split removes every key >= key from the skip list and returns them in a
+// new list with the same comparison function and options. Only the links
+// that cross key are cut, so it runs in O(log n); with WithInsertionOrder
+// the insertion order is split as well, and with WithValueCloner the moved
+// values are cloned, in O(n).
+func (l *List[K, V]) Split(key K) *List[K, V] {
+	return l.split(key, l.compare)
+}
+
+// Split removes every key >= key from the skip list and returns them in a
+// new SkipList with the same key type and options, in O(log n). A key
+// below the smallest one moves every key and one above the largest moves
+// none. A nil or mismatched key yields ErrNilKey or ErrKeyTypeMismatch.
+func (s *SkipList) Split(key interface{}) (*SkipList, error) {
+	if err := s.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	r := &SkipList{keyType: s.keyType}
+	r.list = s.list.split(key, r.compare)
+	if s.access != nil {
+		r.access = newAccessCounter(s.list.opts.accessTopK)
+	}
+	return r, nil
+}
+
+// Split removes every key >= key from the skip list and returns them in a
+// new ConcurrentSkipList
+func (c *ConcurrentSkipList) Split(key interface{}) (*ConcurrentSkipList, error) {
+	c.lockRange(key, nil)
+	defer c.mu.Unlock()
+
+	r, err := c.list.Split(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ConcurrentSkipList{list: r}, nil
+}
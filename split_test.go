@@ -0,0 +1,73 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 100; round++ {
+		l, _ := NewList[int, int](WithRandSource(rand.NewSource(int64(round))), WithInsertionOrder(false))
+		keys := randomKeys(rng, rng.Intn(100), 300)
+		for _, i := range rng.Perm(len(keys)) {
+			l.Insert(keys[i], keys[i])
+		}
+
+		pivot := rng.Intn(320) - 10
+		r := l.Split(pivot)
+
+		verify(t, l)
+		verify(t, r)
+		at, _ := slices.BinarySearch(keys, pivot)
+		if got := slices.Collect(l.Keys()); !slices.Equal(got, keys[:at]) {
+			t.Fatalf("Split(%d) kept %v, want %v", pivot, got, keys[:at])
+		}
+		if got := slices.Collect(r.Keys()); !slices.Equal(got, keys[at:]) {
+			t.Fatalf("Split(%d) moved %v, want %v", pivot, got, keys[at:])
+		}
+	}
+}
+
+func TestSplitComparatorPanic(t *testing.T) {
+	var armed bool
+	var after int
+	for n := 0; n < 120; n += 3 {
+		l, _ := NewListFunc[int, int](panicky(&armed, &after), WithInsertionOrder(false))
+		armed = false
+		for i := 0; i < 50; i++ {
+			l.Insert(i, i)
+		}
+
+		armed, after = true, n
+		var r *List[int, int]
+		panicked := mustPanic(func() { r = l.Split(25) })
+		armed = false
+
+		verify(t, l)
+		if panicked && l.Len() != 50 {
+			t.Fatalf("Panic after %d comparisons left %d keys, want 50", n, l.Len())
+		}
+		if !panicked {
+			verify(t, r)
+			if l.Len() != 25 || r.Len() != 25 {
+				t.Fatalf("Split left %d and %d keys, want 25 and 25", l.Len(), r.Len())
+			}
+		}
+	}
+}
+
+func TestSkipListSplitRejects(t *testing.T) {
+	s := NewSkipList(Int)
+	if _, err := s.Split(nil); !errors.Is(err, ErrNilKey) {
+		t.Fatalf("Split(nil) = %v, want ErrNilKey", err)
+	}
+	if _, err := s.Split("a"); !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("Split(\"a\") = %v, want ErrKeyTypeMismatch", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+// Stats describes the level structure of a skip list at one point in time
+type Stats struct {
+	Level   int       // Current level of the skip list
+	Length  int       // Number of keys
+	Nodes   []int     // Number of nodes whose tower reaches each level
+	AvgSpan []float64 // Average number of keys a forward pointer skips at each level, counting the head
+	MaxSpan []int     // Largest number of keys a forward pointer skips at each level
+}
+
+// Stats walks every level of the skip list and tallies its nodes and the
+// spans of its forward pointers in O(n). With probability p, Nodes[i] is
+// expected to be about Length * p^i. The result is a snapshot and does not
+// follow later changes.
+func (l *List[K, V]) Stats() Stats {
+	st := Stats{
+		Level:   l.level,
+		Length:  l.length,
+		Nodes:   make([]int, l.level),
+		AvgSpan: make([]float64, l.level),
+		MaxSpan: make([]int, l.level),
+	}
+
+	for i := 0; i < l.level; i++ {
+		links, total := 0, 0
+		for n := l.head; n.forward[i] != nil; n = n.forward[i] {
+			links++
+			total += n.span[i]
+			st.MaxSpan[i] = max(st.MaxSpan[i], n.span[i])
+		}
+		st.Nodes[i] = links
+		if links > 0 {
+			st.AvgSpan[i] = float64(total) / float64(links)
+		}
+	}
+
+	return st
+}
+
+// Stats returns a snapshot of the level structure of the skip list, in O(n)
+func (s *SkipList) Stats() Stats {
+	return s.list.Stats()
+}
+
+// Stats returns a snapshot of the level structure of the skip list, in O(n)
+func (c *ConcurrentSkipList) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Stats()
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	if st := NewSkipList(Int).Stats(); st.Level != 1 || st.Length != 0 || !reflect.DeepEqual(st.Nodes, []int{0}) {
+		t.Fatalf("Stats of an empty list = %+v", st)
+	}
+
+	l, _ := NewList[int, int](WithRandSource(rand.NewSource(1)))
+	for _, key := range rand.New(rand.NewSource(2)).Perm(5000) {
+		l.Insert(key, key)
+	}
+	st := l.Stats()
+
+	// Tally the towers and the rank of the last node on every level by hand.
+	nodes := make([]int, l.level)
+	last := make([]int, l.level)
+	rank := 0
+	for n := l.head.forward[0]; n != nil; n = n.forward[0] {
+		rank++
+		for i := range n.forward {
+			nodes[i]++
+			last[i] = rank
+		}
+	}
+	if st.Level != l.level || st.Length != 5000 || !reflect.DeepEqual(st.Nodes, nodes) {
+		t.Fatalf("Stats() = level %d, length %d, nodes %v, want %d, 5000, %v", st.Level, st.Length, st.Nodes, l.level, nodes)
+	}
+	for i := range nodes {
+		// The links of a level, counting the one from the head, cover the ranks up to its last node.
+		if got := st.AvgSpan[i] * float64(st.Nodes[i]); math.Abs(got-float64(last[i])) > 1e-6 {
+			t.Errorf("Level %d: average span %v over %d links covers %v keys, want %d", i, st.AvgSpan[i], st.Nodes[i], got, last[i])
+		}
+		if st.MaxSpan[i] < int(math.Ceil(st.AvgSpan[i])) {
+			t.Errorf("Level %d: max span %d is below the average %v", i, st.MaxSpan[i], st.AvgSpan[i])
+		}
+	}
+	if st.MaxSpan[0] != 1 {
+		t.Errorf("Max span on level 0 = %d, want 1", st.MaxSpan[0])
+	}
+
+	// A snapshot does not follow later changes.
+	nodes0 := st.Nodes[0]
+	l.Clear()
+	if st.Nodes[0] != nodes0 || st.Length != 5000 {
+		t.Error("Stats changed after Clear")
+	}
+}
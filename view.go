@@ -0,0 +1,52 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "bytes"
+
+// GetCopy returns a copy of the []byte value stored under key and whether
+// the key was found with a []byte value. Get returns the stored slice
+// itself, which is shared with every other reader of the key; the copy may
+// be modified and kept freely.
+func (s *SkipList) GetCopy(key interface{}) ([]byte, bool) {
+	value, ok := s.Get(key)
+	b, isBytes := value.([]byte)
+	if !ok || !isBytes {
+		return nil, false
+	}
+	return bytes.Clone(b), true
+}
+
+// View calls fn with the []byte value stored under key, without copying
+// it, and reports whether it did. The slice is valid for the duration of
+// the call. fn must not modify it or retain it after returning; doing so
+// is undefined.
+func (s *SkipList) View(key interface{}, fn func(value []byte)) bool {
+	value, ok := s.Get(key)
+	b, isBytes := value.([]byte)
+	if !ok || !isBytes {
+		return false
+	}
+	fn(b)
+	return true
+}
+
+// GetCopy returns a copy of the []byte value stored under key and whether
+// the key was found with a []byte value
+func (c *ConcurrentSkipList) GetCopy(key interface{}) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.GetCopy(key)
+}
+
+// View calls fn with the []byte value stored under key, without copying
+// it, and reports whether it did. The read lock is held while fn runs, so
+// no writer can replace or delete the key until it returns; fn must not
+// write to the list itself. fn must not modify the slice or retain it
+// after returning; doing so is undefined.
+func (c *ConcurrentSkipList) View(key interface{}, fn func(value []byte)) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.View(key, fn)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Qi Shen. All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package SkipList
+
+import "testing"
+
+func TestGetCopyView(t *testing.T) {
+	s := NewSkipList(String)
+	stored := []byte("value")
+	s.Insert("bytes", stored)
+	s.Insert("string", "value")
+
+	cp, ok := s.GetCopy("bytes")
+	if !ok || string(cp) != "value" {
+		t.Fatalf("GetCopy(bytes) = %q, %v, want value, true", cp, ok)
+	}
+	if &cp[0] == &stored[0] {
+		t.Fatal("GetCopy returned the stored slice")
+	}
+	cp[0] = 'X'
+	if string(stored) != "value" {
+		t.Fatalf("Modifying the copy changed the stored value to %q", stored)
+	}
+
+	var viewed []byte
+	if !s.View("bytes", func(value []byte) { viewed = value }) || &viewed[0] != &stored[0] {
+		t.Fatal("View did not lend the stored slice")
+	}
+
+	for _, key := range []string{"missing", "string"} {
+		if cp, ok := s.GetCopy(key); ok || cp != nil {
+			t.Errorf("GetCopy(%s) = %q, %v, want nil, false", key, cp, ok)
+		}
+		if s.View(key, func([]byte) { t.Errorf("View(%s) called fn", key) }) {
+			t.Errorf("View(%s) = true, want false", key)
+		}
+	}
+}
+
+func TestConcurrentGetCopyView(t *testing.T) {
+	c, _ := NewConcurrentSkipList(String)
+	stored := []byte("value")
+	c.Insert("bytes", stored)
+
+	cp, ok := c.GetCopy("bytes")
+	if !ok || string(cp) != "value" || &cp[0] == &stored[0] {
+		t.Fatalf("GetCopy(bytes) = %q, %v, want a copy of value", cp, ok)
+	}
+
+	// The read lock is held while fn runs, so a writer cannot get in.
+	if !c.View("bytes", func([]byte) {
+		if c.mu.TryLock() {
+			c.mu.Unlock()
+			t.Error("View does not hold the read lock while fn runs")
+		}
+	}) {
+		t.Fatal("View(bytes) = false, want true")
+	}
+	if c.View("missing", func([]byte) { t.Error("View(missing) called fn") }) {
+		t.Error("View(missing) = true, want false")
+	}
+}